@@ -12,8 +12,10 @@ import (
 	"io"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"sync"
 	"syscall"
+	"time"
 
 	"edgexfoundry/app-rfid-llrp-inventory/internal/inventory"
 	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
@@ -34,15 +36,25 @@ const (
 )
 
 type InventoryApp struct {
-	service      interfaces.ApplicationService
-	lc           logger.LoggingClient
-	devService   llrp.DSClient
-	defaultGrp   *llrp.ReaderGroup
-	snapshotReqs chan snapshotDest
-	reports      chan reportData
-	config       inventory.ServiceConfig
-	confUpdateCh chan interface{}
-	publisher    interfaces.BackgroundPublisher
+	service       interfaces.ApplicationService
+	lc            logger.LoggingClient
+	devService    llrp.DSClient
+	groups        *readerGroups
+	snapshotReqs  chan snapshotDest
+	reports       *reportQueue
+	config        inventory.ServiceConfig
+	confUpdateCh  chan interface{}
+	access        tagAccess
+	outbox        *outbox
+	health        *inventory.HealthMonitor
+	readerUpdates chan readerUpdate
+	readingState  chan struct{}
+	schedules     *scheduler
+	outputs       *outputs
+
+	// stopped is closed when the taskLoop exits,
+	// so senders to its channels don't block forever.
+	stopped chan struct{}
 }
 
 type reportData struct {
@@ -50,6 +62,19 @@ type reportData struct {
 	info   inventory.ReportInfo
 }
 
+// readerUpdate holds the changes to a reader's state
+// that affect how its tags depart.
+type readerUpdate struct {
+	device string
+	alerts []inventory.Event
+	// rospec is the reader's ROSpecEvent, if it reported one.
+	rospec *llrp.ROSpecEvent
+	// gpi is the reader's GPIEvent, if it reported one.
+	gpi *llrp.GPIEvent
+	// timestamp is when the reader reported the changes (Unix Epoch milliseconds).
+	timestamp int64
+}
+
 type snapshotDest struct {
 	w      io.Writer
 	result chan error
@@ -57,9 +82,13 @@ type snapshotDest struct {
 
 func NewInventoryApp() *InventoryApp {
 	return &InventoryApp{
-		snapshotReqs: make(chan snapshotDest),
-		reports:      make(chan reportData),
-		confUpdateCh: make(chan interface{}),
+		snapshotReqs:  make(chan snapshotDest),
+		confUpdateCh:  make(chan interface{}),
+		health:        inventory.NewHealthMonitor(),
+		readerUpdates: make(chan readerUpdate, readerUpdatesChSz),
+		readingState:  make(chan struct{}, 1),
+		stopped:       make(chan struct{}),
+		schedules:     newScheduler(),
 	}
 }
 
@@ -82,21 +111,23 @@ func (app *InventoryApp) Initialize() error {
 		return fmt.Errorf("failed to load custom configuration: %w", err)
 	}
 
-	if err = app.config.AppCustom.AppSettings.Validate(); err != nil {
+	if err = app.config.AppCustom.Validate(); err != nil {
 		return fmt.Errorf("failed to validate custom config: %w", err)
 	}
 
+	as := app.config.AppCustom.AppSettings
+	app.reports = newReportQueue(app.lc, as.ReportQueuePolicy, as.ReportQueueSize)
+
 	if err = app.service.ListenForCustomConfigChanges(&app.config.AppCustom, customKey, app.processConfigUpdates); err != nil {
 		return fmt.Errorf("failed to listen for custom config changes: %w", err)
 	}
 
-	app.publisher, err = app.service.AddBackgroundPublisher(1)
-	if err != nil {
-		return fmt.Errorf("failed to add BackgroundPublisher: %w", err)
-	}
-
-	app.defaultGrp = llrp.NewReaderGroup()
 	app.devService = llrp.NewDSClient(app.service.CommandClient(), app.lc)
+	app.outputs = newOutputs(app.lc, app.writeGPO)
+	app.updateOutputRules(app.config.AppCustom)
+	app.groups = newReaderGroups(app.lc)
+	app.loadGroups()
+	app.updateGPISignals(app.config.AppCustom)
 
 	dsName := app.config.AppCustom.AppSettings.DeviceServiceName
 	if dsName == "" {
@@ -111,9 +142,11 @@ func (app *InventoryApp) Initialize() error {
 	app.lc.Debugf("Found %d devices", len(response.Devices))
 	for _, device := range response.Devices {
 		app.lc.Debugf("Attempting to add Reader for device '%s'", device.Name)
-		if err = app.defaultGrp.AddReader(app.devService, device.Name); err != nil {
+		if err = app.addReader(device.Name, device.Labels); err != nil {
 			app.lc.Errorf("Failed to setup device %s: %s", device.Name, err.Error())
+			continue
 		}
+		app.health.SetConnected(device.Name, time.Now())
 	}
 
 	return app.addRoutes()
@@ -131,6 +164,16 @@ func (app *InventoryApp) RunUntilCancelled() error {
 		app.lc.Error("Failed to create cache directory.", "directory", cacheFolder, "error", err.Error())
 	}
 
+	as := app.config.AppCustom.AppSettings
+	var err error
+	app.outbox, err = openOutbox(app.lc, filepath.Join(cacheFolder, outboxFolder), as.OutboxMaxEntries, as.PublishRetryMaxSeconds)
+	if err != nil {
+		app.lc.Error("Failed to open the event outbox; events will be lost if the service stops before they're published.",
+			"error", err.Error())
+	}
+
+	app.loadSchedules()
+
 	ctx, cancel := context.WithCancel(context.Background())
 
 	var wg sync.WaitGroup
@@ -141,6 +184,18 @@ func (app *InventoryApp) RunUntilCancelled() error {
 		app.lc.Info("Task loop has exited.")
 	}()
 
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		app.runSchedules(ctx)
+	}()
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		app.outputs.run(ctx)
+	}()
+
 	// We are doing this because of an issue with running app-functions-sdk inside
 	// of docker-compose where something is hanging and not relinquishing control
 	// back to our code.
@@ -160,7 +215,7 @@ func (app *InventoryApp) RunUntilCancelled() error {
 	}()
 
 	// Subscribe to events.
-	err := app.service.SetDefaultFunctionsPipeline(
+	err = app.service.SetDefaultFunctionsPipeline(
 		app.processEdgeXEvent)
 	if err != nil {
 		return fmt.Errorf("failed to build pipeline: %w", err)
@@ -13,6 +13,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"sync"
 	"time"
 
@@ -32,7 +33,7 @@ const (
 	resourceReaderNotification = "ReaderEventNotification"
 	resourceInventoryEvent     = "InventoryEvent"
 
-	eventChSz = 100
+	readerUpdatesChSz = 16
 )
 
 // processEdgeXEvent is our core processing logic for EdgeX events after they are first
@@ -41,7 +42,7 @@ const (
 // Currently it supports two different event types. The first is reader event notifications which
 // handles events such as readers being connected and disconnected. The second event type is
 // ROAccessReport which is a wrapper around rfid tag read events. These tag readings are sent to
-// a channel which processes them as part of the main taskLoop.
+// a bounded queue which the main taskLoop processes.
 func (app *InventoryApp) processEdgeXEvent(_ interfaces.AppFunctionContext, data interface{}) (bool, interface{}) {
 	if data == nil {
 		return false, errors.New("processEdgeXEvent: was called without any data")
@@ -85,11 +86,13 @@ func (app *InventoryApp) processEdgeXEvent(_ interfaces.AppFunctionContext, data
 				continue
 			}
 
-			if report.TagReportData == nil {
+			// Impinj gateways in Location and Direction modes report tags as Custom parameters.
+			if report.TagReportData == nil && len(report.Custom) == 0 {
 				app.lc.Warn("No tag report data in report.", "device", event.DeviceName)
 			} else {
-				// pass the tag report data to the reports channel to be processed by our taskLoop
-				app.reports <- reportData{report, inventory.NewReportInfo(reading)}
+				app.health.ReportReceived(event.DeviceName, time.Now())
+				// pass the tag report data to the reports queue to be processed by our taskLoop
+				app.reports.push(reportData{report, inventory.NewReportInfo(reading)})
 				app.lc.Trace("New ROAccessReport.",
 					"device", event.DeviceName, "tags", len(report.TagReportData))
 			}
@@ -114,6 +117,8 @@ func (app *InventoryApp) getReadingObjectValue(value interface{}, target interfa
 
 // handleReaderEvent handles an llrp.ReaderEventNotification from the Device Service.
 //
+// It updates the reader's health, publishing ReaderAlert events for any changes,
+// and passes GPI changes to the taskLoop, which publishes GPISignal events for them.
 // If a device reports a new connection event,
 // this adds the reader to the list of managed readers.
 // If a device reports a close event, it removes that reader.
@@ -121,14 +126,35 @@ func (app *InventoryApp) handleReaderEvent(device string, notification *llrp.Rea
 	const connSuccess = llrp.ConnectionAttemptEvent(llrp.ConnSuccess)
 
 	data := notification.ReaderEventNotificationData
+	now := time.Now()
+	alerts := app.health.HandleNotification(device, notification, now)
+	if len(alerts) > 0 {
+		app.lc.Info("Reader health changed.", "device", device, "alerts", len(alerts))
+		app.queueEvents(alerts)
+	}
+	if len(alerts) > 0 || data.ROSpecEvent != nil || data.GPIEvent != nil {
+		// the taskLoop suspends departures at unavailable, idle, or gated readers
+		select {
+		case app.readerUpdates <- readerUpdate{
+			device:    device,
+			alerts:    alerts,
+			rospec:    data.ROSpecEvent,
+			gpi:       data.GPIEvent,
+			timestamp: inventory.NotificationTimestamp(notification, now),
+		}:
+		case <-app.stopped:
+			app.lc.Warn("Task loop stopped; dropped reader update.", "device", device)
+		}
+	}
+
 	switch {
 	case data.ConnectionAttemptEvent != nil && *data.ConnectionAttemptEvent == connSuccess:
-		app.lc.Info(fmt.Sprintf("Adding device to default group: %v", device))
-		return app.defaultGrp.AddReader(app.devService, device)
+		app.lc.Info(fmt.Sprintf("Adding device to its reader group: %v", device))
+		return app.addReader(device, app.deviceLabels(device))
 
 	case data.ConnectionCloseEvent != nil:
-		app.lc.Info(fmt.Sprintf("Removing device from default group: %v", device))
-		app.defaultGrp.RemoveReader(device)
+		app.lc.Info(fmt.Sprintf("Removing device from its reader group: %v", device))
+		app.groups.removeReader(app.devService, device)
 	}
 
 	return nil
@@ -159,14 +185,14 @@ func (app *InventoryApp) requestInventorySnapshot(w io.Writer) error {
 // this taskLoop ensures the modifications are done safely
 // without requiring a ton of lock contention on the inventory itself.
 func (app *InventoryApp) taskLoop(ctx context.Context) {
-	departedCheckSeconds := app.config.AppCustom.AppSettings.DepartedCheckIntervalSeconds
+	departedCheckSeconds := app.config.AppCustom.DepartedCheckSeconds()
 	aggregateDepartedTicker := time.NewTicker(time.Duration(departedCheckSeconds) * time.Second) // #nosec G115
 	ageoutTicker := time.NewTicker(1 * time.Hour)
-	eventCh := make(chan []inventory.Event, eventChSz)
 
 	defer func() {
 		aggregateDepartedTicker.Stop()
 		ageoutTicker.Stop()
+		close(app.stopped)
 	}()
 
 	// load tag data
@@ -184,18 +210,19 @@ func (app *InventoryApp) taskLoop(ctx context.Context) {
 	if len(snapshot) > 0 {
 		app.lc.Info(fmt.Sprintf("Restored %d tags from cache.", len(snapshot)))
 	}
+	// Readers don't read until they're started, so neither should tags depart.
+	app.syncReading(processor, &snapshot)
 
+	// Events are published from the outbox, so a publishing failure only delays them,
+	// and any that remain unpublished at shutdown are published after a restart.
+	publishCtx, stopPublishing := context.WithCancel(context.Background())
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		app.lc.Info("Starting event processor.")
-		for events := range eventCh {
-			if err := app.publishEvents(events); err != nil {
-				app.lc.Error("Failed to push inventory events.", "error", err.Error())
-			}
-		}
-		app.lc.Info("Event processor stopped.")
+		app.lc.Info("Starting event processor.", "queued", app.outbox.stats().Depth)
+		app.outbox.run(publishCtx, app.publishPayload)
+		app.lc.Info("Event processor stopped.", "unpublished", app.outbox.stats().Depth)
 	}()
 
 	app.lc.Info("Starting task loop.")
@@ -203,32 +230,78 @@ func (app *InventoryApp) taskLoop(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			app.lc.Info("Stopping task loop.")
-			close(eventCh)
+			stopPublishing()
+			app.reports.close()
 			app.persistSnapshot(snapshot)
 			wg.Wait()
 			app.lc.Info("Task loop stopped.")
 			return
 
-		case rd := <-app.reports:
+		case <-app.reports.ready:
+			rd, ok := app.reports.pop()
+			if !ok {
+				continue
+			}
+
 			// TODO: we should refactor the ReaderGroup/TagReader
 			//   to unite its tag processing with the TagProcessor code;
 			//   the biggest goal is to perform only a single pass on the TagReportData.
 			//   Secondarily, it would allow us to eliminate the ReaderGroup mutex.
-			if !app.defaultGrp.ProcessTagReport(rd.info.DeviceName, rd.report.TagReportData) {
+			grp, ok := app.groups.groupOf(rd.info.DeviceName)
+			if !ok || !grp.ProcessTagReport(rd.info.DeviceName, rd.report.TagReportData) {
 				// This can only happen if the device didn't exist when we started,
 				// and we never got a Connection message for it.
 				app.lc.Error("Tag Report for unknown device.", "device", rd.info.DeviceName)
 			}
+			accessResults := app.access.dispatch(rd.info.DeviceName, rd.report.TagReportData)
 
+			// Map any memory read results back to the reads that produced them.
+			if ok {
+				rd.info.ReadNames = grp.Behavior().ReadNames()
+			}
 			events, updatedSnapshot := processor.ProcessReport(rd.report, rd.info)
 			if updatedSnapshot != nil {
 				snapshot = updatedSnapshot // always update the snapshot if available
 			}
+
+			// This must follow the report processing so killed tags aren't re-added by it.
+			if len(accessResults) > 0 {
+				accessEvents, accessSnapshot := processor.ProcessAccessResults(rd.info.DeviceName, accessResults)
+				if accessSnapshot != nil {
+					snapshot = accessSnapshot
+				}
+				events = append(events, accessEvents...)
+			}
 			if len(events) > 0 {
 				app.persistSnapshot(snapshot) // only persist when there are inventory events
-				eventCh <- events
+				app.queueEvents(events)
+			}
+
+		case update := <-app.readerUpdates:
+			if updatedSnapshot := processor.ProcessReaderAlerts(update.alerts); updatedSnapshot != nil {
+				snapshot = updatedSnapshot
+			}
+			// With a GPI or Periodic trigger, readers are idle until the trigger starts their ROSpec.
+			grp, ok := app.groups.groupOf(update.device)
+			if ok && update.rospec != nil && grp.IsReading() && grp.Behavior().Triggered() {
+				idle := update.rospec.Event != llrp.ROSpecStarted
+				if updatedSnapshot := processor.SetReaderIdle(update.device, idle); updatedSnapshot != nil {
+					snapshot = updatedSnapshot
+				}
+			}
+			if update.gpi != nil {
+				events, updatedSnapshot := processor.ProcessGPIEvent(update.device, *update.gpi, update.timestamp)
+				if updatedSnapshot != nil {
+					snapshot = updatedSnapshot
+				}
+				if len(events) > 0 {
+					app.queueEvents(events)
+				}
 			}
 
+		case <-app.readingState:
+			app.syncReading(processor, &snapshot)
+
 		case t := <-aggregateDepartedTicker.C:
 			app.lc.Debug("Running AggregateDeparted.", "time", fmt.Sprintf("%v", t))
 
@@ -237,7 +310,7 @@ func (app *InventoryApp) taskLoop(ctx context.Context) {
 					snapshot = updatedSnapshot
 					app.persistSnapshot(snapshot)
 				}
-				eventCh <- events
+				app.queueEvents(events)
 			}
 
 		case t := <-ageoutTicker.C:
@@ -254,19 +327,30 @@ func (app *InventoryApp) taskLoop(ctx context.Context) {
 				continue
 			}
 
-			if err := newConfig.AppSettings.Validate(); err != nil {
+			if err := newConfig.Validate(); err != nil {
 				app.lc.Error("Invalid Configuration configuration.", "error", err.Error())
 				continue
 			}
 
 			app.lc.Info("Configuration updated from keeper.")
+			app.outbox.setLimits(newConfig.AppSettings.OutboxMaxEntries, newConfig.AppSettings.PublishRetryMaxSeconds)
+			app.reports.setLimits(newConfig.AppSettings.ReportQueuePolicy, newConfig.AppSettings.ReportQueueSize)
+			app.updateConfigSchedules(newConfig.Schedules)
+			app.updateOutputRules(*newConfig)
+			app.updateGPISignals(*newConfig)
 			app.lc.Debug("New Configuration config.", "config", fmt.Sprintf("%+v", newConfig))
-			processor.UpdateConfig(*newConfig)
+			if events, updatedSnapshot := processor.UpdateConfig(*newConfig); updatedSnapshot != nil {
+				snapshot = updatedSnapshot
+				app.persistSnapshot(snapshot)
+				if len(events) > 0 {
+					app.queueEvents(events)
+				}
+			}
 
 			// check if we need to change the ticker interval
-			if departedCheckSeconds != newConfig.AppSettings.DepartedCheckIntervalSeconds {
+			if departedCheckSeconds != newConfig.DepartedCheckSeconds() {
 				aggregateDepartedTicker.Stop()
-				departedCheckSeconds = newConfig.AppSettings.DepartedCheckIntervalSeconds
+				departedCheckSeconds = newConfig.DepartedCheckSeconds()
 				aggregateDepartedTicker = time.NewTicker(time.Duration(departedCheckSeconds) * time.Second) // #nosec G115
 				app.lc.Info(fmt.Sprintf("Changing aggregate departed check interval to %d seconds.", departedCheckSeconds))
 			}
@@ -281,6 +365,37 @@ func (app *InventoryApp) taskLoop(ctx context.Context) {
 	}
 }
 
+// syncReading tells the processor which readers are reading.
+// Tags can't depart while every reader group is stopped.
+// While any group is reading, the readers in stopped groups are idle,
+// as are those in groups with a GPI or Periodic trigger, until the trigger starts their ROSpecs.
+func (app *InventoryApp) syncReading(processor *inventory.TagProcessor, snapshot *[]inventory.StaticTag) {
+	update := func(updatedSnapshot []inventory.StaticTag) {
+		if updatedSnapshot != nil {
+			*snapshot = updatedSnapshot
+		}
+	}
+
+	groups := app.groups.all()
+	reading := slices.ContainsFunc(groups, (*llrp.ReaderGroup).IsReading)
+	update(processor.SetReading(reading))
+	if !reading {
+		return
+	}
+
+	for _, grp := range groups {
+		triggered := grp.Behavior().Triggered()
+		for _, name := range grp.ReaderNames() {
+			idle := !grp.IsReading()
+			if !idle && triggered {
+				health, ok := app.health.Health(name)
+				idle = !ok || !health.ROSpecRunning
+			}
+			update(processor.SetReaderIdle(name, idle))
+		}
+	}
+}
+
 func (app *InventoryApp) persistSnapshot(snapshot []inventory.StaticTag) {
 	app.lc.Debug("Persisting inventory snapshot.")
 	data, err := json.Marshal(snapshot)
@@ -296,32 +411,54 @@ func (app *InventoryApp) persistSnapshot(snapshot []inventory.StaticTag) {
 	app.lc.Info("Persisted inventory snapshot.", "tags", len(snapshot))
 }
 
-// publishEvents will publish one or more Inventory Events as a single EdgeX Event with
+// queueEvents adds one or more Inventory Events to the outbox to be published,
+// and fires any OutputRules they match.
+func (app *InventoryApp) queueEvents(events []inventory.Event) {
+	app.outputs.handle(events)
+	payload, err := app.marshalEvents(events)
+	if err != nil {
+		app.lc.Error("Failed to queue inventory events.", "error", err.Error())
+		return
+	}
+	app.outbox.add(payload)
+}
+
+// marshalEvents will marshal one or more Inventory Events as a single EdgeX Event with
 // an EdgeX Reading for each Inventory Event
-func (app *InventoryApp) publishEvents(events []inventory.Event) error {
+func (app *InventoryApp) marshalEvents(events []inventory.Event) ([]byte, error) {
 	// These events are generated by the app-service itself, so we are using serviceKey
 	// for the profile, device, and source names.
 	edgeXEvent := dtos.NewEvent(serviceKey, serviceKey, serviceKey)
 
 	for _, event := range events {
 		resourceName := resourceInventoryEvent + string(event.OfType())
-		app.lc.Debugf("Publishing Inventory Event of type %s: %+v", resourceName, event)
+		app.lc.Debugf("Queueing Inventory Event of type %s: %+v", resourceName, event)
 		edgeXEvent.AddObjectReading(resourceName, event)
 	}
 
 	addRequest := requests.NewAddEventRequest(edgeXEvent)
 	payload, err := json.Marshal(addRequest)
 	if err != nil {
-		return fmt.Errorf("unable to marshal inventory event(s) to publish: %w", err)
+		return nil, fmt.Errorf("unable to marshal inventory event(s) to publish: %w", err)
 	}
 
-	// Need a Context to have values for the placeholders in the configured topic
+	return payload, nil
+}
+
+// publishPayload publishes Inventory Events marshaled by marshalEvents
+// to the Trigger's PublishTopic.
+//
+// It publishes them directly to the message bus, rather than via a BackgroundPublisher,
+// so it only returns nil once the bus has accepted them,
+// and the outbox keeps them until then.
+func (app *InventoryApp) publishPayload(payload []byte) error {
+	// Need a Context to fill in the placeholders in the configured topic
 	context := app.service.BuildContext(uuid.NewString(), common.ContentTypeJSON)
-	context.AddValue(interfaces.PROFILENAME, edgeXEvent.ProfileName)
-	context.AddValue(interfaces.DEVICENAME, edgeXEvent.DeviceName)
-	context.AddValue(interfaces.SOURCENAME, edgeXEvent.SourceName)
+	context.AddValue(interfaces.PROFILENAME, serviceKey)
+	context.AddValue(interfaces.DEVICENAME, serviceKey)
+	context.AddValue(interfaces.SOURCENAME, serviceKey)
 
-	if err := app.publisher.Publish(payload, context); err != nil {
+	if err := context.Publish(payload, common.ContentTypeJSON); err != nil {
 		return fmt.Errorf("unable to publish inventory event(s): %w", err)
 	}
 
@@ -0,0 +1,55 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"testing"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleReaderEvent_stopped(t *testing.T) {
+	app := NewInventoryApp()
+	app.lc = logger.NewMockClient()
+	app.readerUpdates = make(chan readerUpdate, 1)
+	gpi := &llrp.ReaderEventNotification{ReaderEventNotificationData: llrp.ReaderEventNotificationData{
+		GPIEvent: &llrp.GPIEvent{Port: 1, Event: true},
+	}}
+
+	require.NoError(t, app.handleReaderEvent("reader", gpi))
+	require.Len(t, app.readerUpdates, 1)
+	update := <-app.readerUpdates
+	assert.Equal(t, "reader", update.device)
+	assert.Equal(t, gpi.ReaderEventNotificationData.GPIEvent, update.gpi)
+
+	// once the taskLoop exits, updates are dropped rather than blocking the pipeline
+	app.readerUpdates <- update
+	close(app.stopped)
+	handled := make(chan error)
+	go func() { handled <- app.handleReaderEvent("reader", gpi) }()
+	select {
+	case err := <-handled:
+		assert.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("handleReaderEvent blocked after the task loop stopped")
+	}
+}
+
+func TestSignalReadingState(t *testing.T) {
+	app := NewInventoryApp()
+
+	// signals coalesce, and don't block without a task loop to receive them
+	app.signalReadingState()
+	app.signalReadingState()
+	require.Len(t, app.readingState, 1)
+	<-app.readingState
+	assert.Empty(t, app.readingState)
+}
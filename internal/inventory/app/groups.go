@@ -0,0 +1,689 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/inventory"
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+const (
+	defaultGroup   = "default"
+	groupCacheFile = "groups.json"
+)
+
+var (
+	errUnknownGroup = errors.New("unknown reader group")
+	errInvalidGroup = errors.New("invalid reader group")
+)
+
+// groupSpec defines a named reader group and which readers belong to it.
+//
+// A reader belongs to the first group that lists its device name,
+// or if none do, the first group that lists one of its device labels.
+// Readers that don't belong to any named group belong to the default group.
+type groupSpec struct {
+	Name    string   `json:"name"`
+	Readers []string `json:"readers,omitempty"`
+	Labels  []string `json:"labels,omitempty"`
+	// Behavior and Environment, if set, replace those of the group.
+	Behavior    *llrp.Behavior    `json:"behavior,omitempty"`
+	Environment *llrp.Environment `json:"environment,omitempty"`
+}
+
+// groupInfo describes a reader group's current state.
+type groupInfo struct {
+	Name        string           `json:"name"`
+	Readers     []string         `json:"readers"`
+	Labels      []string         `json:"labels"`
+	Members     []string         `json:"members"`
+	Behavior    llrp.Behavior    `json:"behavior"`
+	Environment llrp.Environment `json:"environment"`
+	Reading     bool             `json:"reading"`
+}
+
+// readerGroups assigns readers to named ReaderGroups.
+// There's always a default group, which can't be deleted.
+type readerGroups struct {
+	lc logger.LoggingClient
+	// changes serializes changes to the groups, which configure readers via the device service.
+	// mu guards the fields below against concurrent lookups, but isn't held during that I/O.
+	// The fields are only written while holding both,
+	// so code holding changes may read them without mu.
+	changes sync.Mutex
+	mu      sync.RWMutex
+	specs   []groupSpec                  // named groups, in the order they were created
+	groups  map[string]*llrp.ReaderGroup // by name, including the default group
+	members map[string]string            // the group of each managed reader
+	labels  map[string][]string          // the device labels of each managed reader
+	gpis    map[string][]uint16          // the GPI ports with GPISignals at each reader
+}
+
+func newReaderGroups(lc logger.LoggingClient) *readerGroups {
+	return &readerGroups{
+		lc:      lc,
+		groups:  map[string]*llrp.ReaderGroup{defaultGroup: llrp.NewReaderGroup()},
+		members: map[string]string{},
+		labels:  map[string][]string{},
+	}
+}
+
+// group returns the named ReaderGroup.
+func (rgs *readerGroups) group(name string) (*llrp.ReaderGroup, bool) {
+	rgs.mu.RLock()
+	defer rgs.mu.RUnlock()
+	g, ok := rgs.groups[name]
+	return g, ok
+}
+
+// groupOf returns the ReaderGroup managing the named reader.
+func (rgs *readerGroups) groupOf(device string) (*llrp.ReaderGroup, bool) {
+	rgs.mu.RLock()
+	defer rgs.mu.RUnlock()
+	name, ok := rgs.members[device]
+	if !ok {
+		return nil, false
+	}
+	return rgs.groups[name], true
+}
+
+// hasReader returns true if any group manages the named reader.
+func (rgs *readerGroups) hasReader(device string) bool {
+	rgs.mu.RLock()
+	defer rgs.mu.RUnlock()
+	_, ok := rgs.members[device]
+	return ok
+}
+
+// readerNames returns the names of the readers in every group.
+func (rgs *readerGroups) readerNames() []string {
+	rgs.mu.RLock()
+	defer rgs.mu.RUnlock()
+	names := make([]string, 0, len(rgs.members))
+	for name := range rgs.members {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// all returns every ReaderGroup, starting with the default group.
+func (rgs *readerGroups) all() []*llrp.ReaderGroup {
+	rgs.mu.RLock()
+	defer rgs.mu.RUnlock()
+	groups := []*llrp.ReaderGroup{rgs.groups[defaultGroup]}
+	for _, spec := range rgs.specs {
+		groups = append(groups, rgs.groups[spec.Name])
+	}
+	return groups
+}
+
+// info returns the named group's current state.
+func (rgs *readerGroups) info(name string) (groupInfo, bool) {
+	rgs.mu.RLock()
+	defer rgs.mu.RUnlock()
+	return rgs.infoLocked(name)
+}
+
+// infos returns the current state of every group, starting with the default group.
+func (rgs *readerGroups) infos() []groupInfo {
+	rgs.mu.RLock()
+	defer rgs.mu.RUnlock()
+	info, _ := rgs.infoLocked(defaultGroup)
+	infos := []groupInfo{info}
+	for _, spec := range rgs.specs {
+		info, _ := rgs.infoLocked(spec.Name)
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+func (rgs *readerGroups) infoLocked(name string) (groupInfo, bool) {
+	g, ok := rgs.groups[name]
+	if !ok {
+		return groupInfo{}, false
+	}
+
+	info := groupInfo{
+		Name:        name,
+		Readers:     []string{},
+		Labels:      []string{},
+		Members:     g.ReaderNames(),
+		Behavior:    g.Behavior(),
+		Environment: g.Environment(),
+		Reading:     g.IsReading(),
+	}
+	slices.Sort(info.Members)
+	if i := rgs.specIndex(name); i >= 0 {
+		info.Readers = append(info.Readers, rgs.specs[i].Readers...)
+		info.Labels = append(info.Labels, rgs.specs[i].Labels...)
+	}
+	return info, true
+}
+
+// savedSpecs returns the spec of the default group, followed by those of the named groups,
+// with their current Behavior and Environment.
+func (rgs *readerGroups) savedSpecs() []groupSpec {
+	rgs.mu.RLock()
+	defer rgs.mu.RUnlock()
+	specs := make([]groupSpec, 0, len(rgs.specs)+1)
+	for _, spec := range append([]groupSpec{{Name: defaultGroup}}, rgs.specs...) {
+		g := rgs.groups[spec.Name]
+		b, e := g.Behavior(), g.Environment()
+		spec.Behavior, spec.Environment = &b, &e
+		specs = append(specs, spec)
+	}
+	return specs
+}
+
+func (rgs *readerGroups) specIndex(name string) int {
+	return slices.IndexFunc(rgs.specs, func(s groupSpec) bool { return s.Name == name })
+}
+
+// assign returns the name of the group to which the reader belongs,
+// according to the specs of the named groups.
+func assign(specs []groupSpec, device string, labels []string) string {
+	for _, spec := range specs {
+		if slices.Contains(spec.Readers, device) {
+			return spec.Name
+		}
+	}
+
+	for _, spec := range specs {
+		if slices.ContainsFunc(spec.Labels, func(l string) bool { return slices.Contains(labels, l) }) {
+			return spec.Name
+		}
+	}
+
+	return defaultGroup
+}
+
+// setMember records the group the reader is in, or if group is empty, that it's in none.
+// The caller must hold changes, but not mu.
+func (rgs *readerGroups) setMember(device, group string) {
+	rgs.mu.Lock()
+	defer rgs.mu.Unlock()
+	if group == "" {
+		delete(rgs.members, device)
+		return
+	}
+	rgs.members[device] = group
+}
+
+// addReader adds the reader to the group to which it belongs,
+// removing it from any other group.
+func (rgs *readerGroups) addReader(ds llrp.DSClient, device string, labels []string) (string, error) {
+	rgs.changes.Lock()
+	defer rgs.changes.Unlock()
+
+	rgs.mu.Lock()
+	rgs.labels[device] = labels
+	rgs.mu.Unlock()
+
+	target := assign(rgs.specs, device, labels)
+	if current, ok := rgs.members[device]; ok && current != target {
+		rgs.removeFrom(ds, current, device)
+	}
+
+	if err := rgs.groups[target].AddReader(ds, device); err != nil {
+		rgs.setMember(device, "")
+		return target, err
+	}
+	rgs.setMember(device, target)
+	rgs.enableGPIs(ds, target, device)
+	return target, nil
+}
+
+// setGPIs replaces the GPI ports with GPISignals at each reader,
+// and if they changed, enables them at the managed readers that have any.
+func (rgs *readerGroups) setGPIs(ds llrp.DSClient, gpis map[string][]uint16) {
+	rgs.changes.Lock()
+	defer rgs.changes.Unlock()
+
+	if maps.EqualFunc(rgs.gpis, gpis, slices.Equal) {
+		return
+	}
+
+	rgs.mu.Lock()
+	rgs.gpis = gpis
+	rgs.mu.Unlock()
+
+	for device, group := range rgs.members {
+		rgs.enableGPIs(ds, group, device)
+	}
+}
+
+// enableGPIs enables the reader's GPI ports that have GPISignals, if any.
+// Adding a reader to a group resets its configuration, so this must follow it.
+// A reader that can't report its GPIs is still usable, so the error is logged rather than returned.
+// The caller must hold changes.
+func (rgs *readerGroups) enableGPIs(ds llrp.DSClient, group, device string) {
+	ports := rgs.gpis[device]
+	if len(ports) == 0 {
+		return
+	}
+	if err := rgs.groups[group].EnableGPIs(ds, device, ports); err != nil {
+		rgs.lc.Warn("Failed to enable the reader's GPI ports; its GPI signals won't change.",
+			"device", device, "group", group, "error", err.Error())
+	}
+}
+
+// removeReader removes the reader from its group, if it's in one.
+func (rgs *readerGroups) removeReader(ds llrp.DSClient, device string) {
+	rgs.changes.Lock()
+	defer rgs.changes.Unlock()
+
+	if current, ok := rgs.members[device]; ok {
+		rgs.removeFrom(ds, current, device)
+	}
+
+	rgs.mu.Lock()
+	delete(rgs.members, device)
+	delete(rgs.labels, device)
+	rgs.mu.Unlock()
+}
+
+// removeFrom removes the reader from the named group,
+// and updates the ROSpecs of the group's other readers
+// if they depend on the number of readers in the group.
+// Failing to update them only makes their ROSpecs less efficient,
+// so the error is logged rather than returned.
+// The caller must hold changes.
+func (rgs *readerGroups) removeFrom(ds llrp.DSClient, group, device string) {
+	g := rgs.groups[group]
+	g.RemoveReader(device)
+	if err := g.UpdateNearbyReaders(ds); err != nil {
+		rgs.lc.Warn("Failed to update ROSpecs for the number of nearby readers.",
+			"group", group, "error", err.Error())
+	}
+}
+
+// validateGroupSpec returns an error if the spec can't be used for a named group.
+func validateGroupSpec(spec groupSpec) error {
+	switch spec.Name {
+	case "":
+		return fmt.Errorf("%w: group has no name", errInvalidGroup)
+	case defaultGroup:
+		return fmt.Errorf("%w: the %q group's readers can't be assigned", errInvalidGroup, defaultGroup)
+	}
+	return nil
+}
+
+// create adds a new named group and moves into it the readers that now belong to it.
+// If they can't all be moved, the group isn't created.
+func (rgs *readerGroups) create(ds llrp.DSClient, spec groupSpec) error {
+	if err := validateGroupSpec(spec); err != nil {
+		return err
+	}
+
+	rgs.changes.Lock()
+	defer rgs.changes.Unlock()
+
+	if _, ok := rgs.groups[spec.Name]; ok {
+		return fmt.Errorf("%w: group %q already exists", errInvalidGroup, spec.Name)
+	}
+
+	// The group has no readers yet, so these can't fail;
+	// the readers moving into it validate them.
+	g := llrp.NewReaderGroup()
+	if spec.Behavior != nil {
+		_ = g.SetBehavior(ds, *spec.Behavior)
+	}
+	if spec.Environment != nil {
+		_ = g.SetEnvironment(ds, *spec.Environment)
+	}
+
+	spec.Behavior, spec.Environment = nil, nil
+	specs := append(slices.Clip(rgs.specs), spec)
+
+	// Readers can be found in the group while they move into it,
+	// but it's not listed among the groups until they all have.
+	rgs.mu.Lock()
+	rgs.groups[spec.Name] = g
+	rgs.mu.Unlock()
+
+	if err := rgs.move(ds, rgs.moves(specs)); err != nil {
+		rgs.mu.Lock()
+		delete(rgs.groups, spec.Name)
+		rgs.mu.Unlock()
+		return err
+	}
+
+	rgs.mu.Lock()
+	rgs.specs = specs
+	rgs.mu.Unlock()
+	return nil
+}
+
+// configure replaces the named group's Behavior and Environment, if they're set,
+// without changing which readers belong to it.
+func (rgs *readerGroups) configure(ds llrp.DSClient, name string, b *llrp.Behavior, e *llrp.Environment) error {
+	rgs.changes.Lock()
+	defer rgs.changes.Unlock()
+	return rgs.configureLocked(ds, name, b, e)
+}
+
+// configureLocked is configure for callers holding changes.
+func (rgs *readerGroups) configureLocked(ds llrp.DSClient, name string, b *llrp.Behavior, e *llrp.Environment) error {
+	g, ok := rgs.groups[name]
+	if !ok {
+		return fmt.Errorf("%w: %q", errUnknownGroup, name)
+	}
+
+	if b != nil {
+		if err := g.SetBehavior(ds, *b); err != nil {
+			return err
+		}
+	}
+	if e != nil {
+		if err := g.SetEnvironment(ds, *e); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// update replaces the named group's Behavior and Environment, if they're set,
+// as well as the readers and labels that belong to it,
+// then moves readers to the groups they now belong to.
+// Only the default group's Behavior and Environment can be changed.
+// If the readers can't all be moved, the readers and labels aren't replaced,
+// though the Behavior and Environment are.
+func (rgs *readerGroups) update(ds llrp.DSClient, spec groupSpec) error {
+	rgs.changes.Lock()
+	defer rgs.changes.Unlock()
+
+	if _, ok := rgs.groups[spec.Name]; !ok {
+		return fmt.Errorf("%w: %q", errUnknownGroup, spec.Name)
+	}
+
+	i := rgs.specIndex(spec.Name)
+	if i < 0 && (len(spec.Readers) != 0 || len(spec.Labels) != 0) {
+		return fmt.Errorf("%w: the %q group's readers can't be assigned", errInvalidGroup, spec.Name)
+	}
+
+	if err := rgs.configureLocked(ds, spec.Name, spec.Behavior, spec.Environment); err != nil {
+		return err
+	}
+
+	if i < 0 {
+		return nil
+	}
+	spec.Behavior, spec.Environment = nil, nil
+	specs := slices.Clone(rgs.specs)
+	specs[i] = spec
+	if err := rgs.move(ds, rgs.moves(specs)); err != nil {
+		return err
+	}
+
+	rgs.mu.Lock()
+	rgs.specs = specs
+	rgs.mu.Unlock()
+	return nil
+}
+
+// delete removes the named group, moving its readers to the groups they now belong to.
+// If they can't all be moved, the group isn't deleted.
+func (rgs *readerGroups) delete(ds llrp.DSClient, name string) error {
+	if name == defaultGroup {
+		return fmt.Errorf("%w: the %q group can't be deleted", errInvalidGroup, defaultGroup)
+	}
+
+	rgs.changes.Lock()
+	defer rgs.changes.Unlock()
+
+	i := rgs.specIndex(name)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", errUnknownGroup, name)
+	}
+
+	specs := slices.Delete(slices.Clone(rgs.specs), i, i+1)
+	if err := rgs.move(ds, rgs.moves(specs)); err != nil {
+		return err
+	}
+
+	rgs.mu.Lock()
+	rgs.specs = specs
+	delete(rgs.groups, name)
+	rgs.mu.Unlock()
+	return nil
+}
+
+// move is a reader's change of group.
+type move struct {
+	device   string
+	from, to string
+}
+
+// moves returns the moves needed for every reader to be in the group it belongs to
+// according to the specs, in order of the readers' names.
+// The caller must hold changes.
+func (rgs *readerGroups) moves(specs []groupSpec) []move {
+	var moves []move
+	for device, current := range rgs.members {
+		if target := assign(specs, device, rgs.labels[device]); target != current {
+			moves = append(moves, move{device: device, from: current, to: target})
+		}
+	}
+	slices.SortFunc(moves, func(a, b move) int { return strings.Compare(a.device, b.device) })
+	return moves
+}
+
+// move moves the readers to their new groups.
+//
+// The moves are all or nothing: if any reader can't use its new group's Behavior and Environment,
+// none are moved, and if one fails to move, those already moved are returned to their old groups.
+// The caller must hold changes, but not mu.
+func (rgs *readerGroups) move(ds llrp.DSClient, moves []move) error {
+	for _, m := range moves {
+		to := rgs.groups[m.to]
+		if err := rgs.groups[m.from].ValidateReader(m.device, to.Behavior(), to.Environment()); err != nil {
+			return fmt.Errorf("%w: can't move %q to group %q: %w", errInvalidGroup, m.device, m.to, err)
+		}
+	}
+
+	for i, m := range moves {
+		err := rgs.moveReader(ds, m)
+		if err == nil {
+			continue
+		}
+
+		errs := llrp.MultiErr{err}
+		for _, m := range slices.Backward(moves[:i]) {
+			if err := rgs.moveReader(ds, move{device: m.device, from: m.to, to: m.from}); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errs
+	}
+	return nil
+}
+
+// moveReader moves the reader to its new group, and starts it if that group is reading.
+// If it can't be added to the new group, it's returned to its old one,
+// and if that fails too, it's no longer in any group.
+// The caller must hold changes, but not mu.
+func (rgs *readerGroups) moveReader(ds llrp.DSClient, m move) error {
+	rgs.removeFrom(ds, m.from, m.device)
+
+	group, err := m.to, rgs.groups[m.to].AddReader(ds, m.device)
+	if err != nil {
+		err = fmt.Errorf("failed to move %q to group %q: %w", m.device, m.to, err)
+		group = m.from
+		if returnErr := rgs.groups[m.from].AddReader(ds, m.device); returnErr != nil {
+			rgs.lc.Error("Failed to return reader to its group.",
+				"device", m.device, "group", m.from, "error", returnErr.Error())
+			rgs.setMember(m.device, "")
+			return err
+		}
+	}
+
+	rgs.setMember(m.device, group)
+	rgs.enableGPIs(ds, group, m.device)
+	if err := rgs.groups[group].StartReader(ds, m.device); err != nil {
+		rgs.lc.Warn("Failed to start reader in its group.", "device", m.device, "group", group, "error", err.Error())
+	}
+	return err
+}
+
+// addReader adds the named device to the reader group it belongs to,
+// and tells the task loop, which tracks whether each group's readers are idle.
+func (app *InventoryApp) addReader(device string, labels []string) error {
+	group, err := app.groups.addReader(app.devService, device, labels)
+	app.signalReadingState()
+	if err != nil {
+		return err
+	}
+	app.lc.Info("Added reader to group.", "device", device, "group", group)
+	return nil
+}
+
+// updateGPISignals enables the GPI ports of the configured GPISignals at their readers,
+// unless the signals are invalid, in which case the TagProcessor keeps the current ones too.
+func (app *InventoryApp) updateGPISignals(cfg inventory.CustomConfig) {
+	if err := inventory.ValidateGPISignals(cfg.GPISignals); err != nil {
+		return
+	}
+
+	gpis := make(map[string][]uint16)
+	for _, s := range cfg.GPISignals {
+		gpis[s.Device] = append(gpis[s.Device], s.Port)
+	}
+	for _, ports := range gpis {
+		slices.Sort(ports)
+	}
+	app.groups.setGPIs(app.devService, gpis)
+}
+
+// deviceLabels returns the device's labels from core metadata.
+func (app *InventoryApp) deviceLabels(device string) []string {
+	resp, err := app.service.DeviceClient().DeviceByName(context.Background(), device)
+	if err != nil {
+		app.lc.Warn("Failed to get device labels; reader groups won't match them.",
+			"device", device, "error", err.Error())
+		return nil
+	}
+	return resp.Device.Labels
+}
+
+// setGroupEnvironment changes the named group's Environment.
+func (app *InventoryApp) setGroupEnvironment(name string, e llrp.Environment) error {
+	err := app.groups.configure(app.devService, name, nil, &e)
+	app.saveGroups()
+	return err
+}
+
+// setGroupBehavior changes the named group's Behavior,
+// and tells the task loop, since whether its readers wait for a trigger may have changed.
+func (app *InventoryApp) setGroupBehavior(name string, b llrp.Behavior) error {
+	err := app.groups.configure(app.devService, name, &b, nil)
+	app.saveGroups()
+	app.signalReadingState()
+	return err
+}
+
+// loadGroups restores the reader groups saved in the cache.
+func (app *InventoryApp) loadGroups() {
+	data, err := os.ReadFile(filepath.Join(cacheFolder, groupCacheFile))
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			app.lc.Warn("Failed to load reader groups.", "error", err.Error())
+		}
+		return
+	}
+
+	var specs []groupSpec
+	if err := json.Unmarshal(data, &specs); err != nil {
+		app.lc.Warn("Failed to unmarshal reader groups.", "error", err.Error())
+		return
+	}
+
+	for _, spec := range specs {
+		var err error
+		if spec.Name == defaultGroup {
+			err = app.groups.configure(app.devService, spec.Name, spec.Behavior, spec.Environment)
+		} else {
+			err = app.groups.create(app.devService, spec)
+		}
+		if err != nil {
+			app.lc.Warn("Failed to restore reader group.", "group", spec.Name, "error", err.Error())
+		}
+	}
+	app.lc.Info(fmt.Sprintf("Restored %d reader groups from cache.", len(specs)))
+}
+
+// saveGroups persists the reader groups to the cache,
+// including the default group's Behavior and Environment.
+func (app *InventoryApp) saveGroups() {
+	data, err := json.Marshal(app.groups.savedSpecs())
+	if err != nil {
+		app.lc.Warn("Failed to marshal reader groups.", "error", err.Error())
+		return
+	}
+
+	if err := os.WriteFile(filepath.Join(cacheFolder, groupCacheFile), data, filePerm); err != nil {
+		app.lc.Warn("Failed to persist reader groups.", "error", err.Error())
+	}
+}
+
+// startGroups starts the readers in the named group, or every group if name is empty,
+// and tells the task loop that the reading state changed.
+func (app *InventoryApp) startGroups(name string) error {
+	return app.runGroups(name, func(g *llrp.ReaderGroup) error { return g.StartAll(app.devService) })
+}
+
+// stopGroups stops the readers in the named group, or every group if name is empty,
+// and tells the task loop that the reading state changed.
+func (app *InventoryApp) stopGroups(name string) error {
+	return app.runGroups(name, func(g *llrp.ReaderGroup) error { return g.StopAll(app.devService) })
+}
+
+// signalReadingState tells the task loop that the reading state may have changed.
+// The task loop reads the current state when it handles the signal,
+// so a signal that's already pending covers this one.
+func (app *InventoryApp) signalReadingState() {
+	select {
+	case app.readingState <- struct{}{}:
+	default:
+	}
+}
+
+func (app *InventoryApp) runGroups(name string, f func(g *llrp.ReaderGroup) error) error {
+	groups := app.groups.all()
+	if name != "" {
+		g, ok := app.groups.group(name)
+		if !ok {
+			return fmt.Errorf("%w: %q", errUnknownGroup, name)
+		}
+		groups = []*llrp.ReaderGroup{g}
+	}
+
+	var errs llrp.MultiErr
+	for _, g := range groups {
+		if err := f(g); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	app.signalReadingState()
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
@@ -0,0 +1,346 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"testing"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/dtos/responses"
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// testReaderCaps are the capabilities of a reader without vendor extensions.
+var testReaderCaps = llrp.GetReaderCapabilitiesResponse{
+	GeneralDeviceCapabilities: &llrp.GeneralDeviceCapabilities{
+		MaxSupportedAntennas: 1,
+		GPIOCapabilities:     llrp.GPIOCapabilities{NumGPIs: 1, NumGPOs: 1},
+	},
+	LLRPCapabilities: &llrp.LLRPCapabilities{MaxROSpecs: 1, MaxSpecsPerROSpec: 1, MaxAccessSpecs: 1},
+	RegulatoryCapabilities: &llrp.RegulatoryCapabilities{
+		UHFBandCapabilities: &llrp.UHFBandCapabilities{
+			TransmitPowerLevels: []llrp.TransmitPowerLevelTableEntry{{Index: 1, TransmitPowerValue: 3000}},
+			FrequencyInformation: llrp.FrequencyInformation{
+				Hopping:            true,
+				FrequencyHopTables: []llrp.FrequencyHopTable{{HopTableID: 1, Frequencies: []llrp.Kilohertz{902750}}},
+			},
+			C1G2RFModes: llrp.UHFC1G2RFModeTable{UHFC1G2RFModeTableEntries: []llrp.UHFC1G2RFModeTableEntry{{
+				ModeID:              1,
+				DivideRatio:         llrp.DRSixtyFourToThree,
+				Modulation:          llrp.Miller4,
+				BackscatterDataRate: 256000,
+				MinTariTime:         6250,
+				MaxTariTime:         6250,
+			}}},
+		},
+	},
+	C1G2LLRPCapabilities: &llrp.C1G2LLRPCapabilities{},
+}
+
+// testDevices is a device service whose commands succeed, unless they're made to fail,
+// and which records the commands sent to each device.
+type testDevices struct {
+	mu       sync.Mutex
+	commands map[string][]string
+	configs  map[string][]llrp.SetReaderConfig
+	failNext map[string]bool
+	// onSet, if set, is called while handling each set command.
+	onSet func()
+}
+
+// setCommand records the command, along with the reader config it sets, if any,
+// and fails it if the device's next command should fail.
+func (td *testDevices) setCommand(device, command string, settings map[string]any) errors.EdgeX {
+	var conf llrp.SetReaderConfig
+	if data, ok := settings["ReaderConfig"]; ok {
+		b, err := json.Marshal(data)
+		if err == nil {
+			err = json.Unmarshal(b, &conf)
+		}
+		if err != nil {
+			return errors.NewCommonEdgeX(errors.KindContractInvalid, "bad reader config", err)
+		}
+	}
+
+	td.mu.Lock()
+	td.commands[device] = append(td.commands[device], command)
+	if _, ok := settings["ReaderConfig"]; ok {
+		td.configs[device] = append(td.configs[device], conf)
+	}
+	fail := td.failNext[device]
+	delete(td.failNext, device)
+	onSet := td.onSet
+	td.mu.Unlock()
+
+	if onSet != nil {
+		onSet()
+	}
+	if fail {
+		return errors.NewCommonEdgeX(errors.KindServerError, "command failed", nil)
+	}
+	return nil
+}
+
+// failNextCommand makes the device's next set command fail.
+func (td *testDevices) failNextCommand(device string) {
+	td.mu.Lock()
+	td.failNext[device] = true
+	td.mu.Unlock()
+}
+
+// sent returns the commands sent to the device since the last call, and forgets them.
+func (td *testDevices) sent(device string) []string {
+	td.mu.Lock()
+	defer td.mu.Unlock()
+	commands := td.commands[device]
+	delete(td.commands, device)
+	return commands
+}
+
+// sentConfigs returns the reader configs sent to the device since the last call, and forgets them.
+func (td *testDevices) sentConfigs(device string) []llrp.SetReaderConfig {
+	td.mu.Lock()
+	defer td.mu.Unlock()
+	configs := td.configs[device]
+	delete(td.configs, device)
+	return configs
+}
+
+func newTestDevices(t *testing.T) (*testDevices, llrp.DSClient) {
+	t.Helper()
+	return newTestDevicesWith(t, testReaderCaps)
+}
+
+// newTestDevicesWith returns a device service whose devices have the given capabilities.
+func newTestDevicesWith(t *testing.T, caps llrp.GetReaderCapabilitiesResponse) (*testDevices, llrp.DSClient) {
+	t.Helper()
+	td := &testDevices{
+		commands: map[string][]string{},
+		configs:  map[string][]llrp.SetReaderConfig{},
+		failNext: map[string]bool{},
+	}
+
+	event := dtos.NewEvent("profile", "device", "ReaderCapabilities")
+	event.AddObjectReading("ReaderCapabilities", caps)
+	resp := responses.NewEventResponse("", "", http.StatusOK, event)
+
+	client := &mocks.CommandClient{}
+	client.On("IssueGetCommandByName", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(&resp, nil)
+	client.On("IssueSetCommandByName", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, device, command string, settings map[string]any) (common.BaseResponse, errors.EdgeX) {
+			return common.BaseResponse{}, td.setCommand(device, command, settings)
+		})
+	return td, llrp.NewDSClient(client, logger.NewMockClient())
+}
+
+// newTestGroups returns reader groups with the readers in the default group.
+func newTestGroups(t *testing.T, ds llrp.DSClient, readers map[string][]string) *readerGroups {
+	t.Helper()
+	rgs := newReaderGroups(logger.NewMockClient())
+	for device, labels := range readers {
+		group, err := rgs.addReader(ds, device, labels)
+		require.NoError(t, err)
+		require.Equal(t, defaultGroup, group)
+	}
+	return rgs
+}
+
+func members(t *testing.T, rgs *readerGroups, group string) []string {
+	t.Helper()
+	info, ok := rgs.info(group)
+	require.True(t, ok, "group %q doesn't exist", group)
+	return info.Members
+}
+
+// namedSpecs returns the saved specs of the named groups.
+func namedSpecs(t *testing.T, rgs *readerGroups) []groupSpec {
+	t.Helper()
+	specs := rgs.savedSpecs()
+	require.NotEmpty(t, specs)
+	require.Equal(t, defaultGroup, specs[0].Name, "the default group is saved first")
+	return specs[1:]
+}
+
+func TestReaderGroups_move(t *testing.T) {
+	td, ds := newTestDevices(t)
+	rgs := newTestGroups(t, ds, map[string][]string{"r1": {"dock"}, "r2": nil})
+
+	// lookups aren't blocked while readers are configured
+	td.onSet = func() { rgs.hasReader("r1") }
+
+	require.NoError(t, rgs.create(ds, groupSpec{Name: "Dock", Labels: []string{"dock"}}))
+	assert.Equal(t, []string{"r1"}, members(t, rgs, "Dock"))
+	assert.Equal(t, []string{"r2"}, members(t, rgs, defaultGroup))
+	grp, ok := rgs.groupOf("r1")
+	require.True(t, ok)
+	assert.True(t, grp.HasReader("r1"))
+
+	require.NoError(t, rgs.update(ds, groupSpec{Name: "Dock", Readers: []string{"r2"}}))
+	assert.Equal(t, []string{"r2"}, members(t, rgs, "Dock"))
+	assert.Equal(t, []string{"r1"}, members(t, rgs, defaultGroup))
+
+	require.NoError(t, rgs.delete(ds, "Dock"))
+	_, ok = rgs.info("Dock")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"r1", "r2"}, members(t, rgs, defaultGroup))
+	assert.Empty(t, namedSpecs(t, rgs))
+}
+
+func TestReaderGroups_startMoved(t *testing.T) {
+	td, ds := newTestDevices(t)
+	rgs := newTestGroups(t, ds, map[string][]string{"r1": nil})
+
+	require.NoError(t, rgs.create(ds, groupSpec{Name: "Dock"}))
+	dock, ok := rgs.group("Dock")
+	require.True(t, ok)
+	require.NoError(t, dock.StartAll(ds))
+	td.sent("r1")
+
+	// readers moved into a reading group are started
+	require.NoError(t, rgs.update(ds, groupSpec{Name: "Dock", Readers: []string{"r1"}}))
+	assert.Contains(t, td.sent("r1"), "enableROSpec")
+
+	// but not those moved into a stopped group
+	require.NoError(t, rgs.update(ds, groupSpec{Name: "Dock"}))
+	assert.Equal(t, []string{"r1"}, members(t, rgs, defaultGroup))
+	assert.NotContains(t, td.sent("r1"), "enableROSpec")
+}
+
+func TestReaderGroups_createInvalid(t *testing.T) {
+	td, ds := newTestDevices(t)
+	rgs := newTestGroups(t, ds, map[string][]string{"r1": nil})
+	td.sent("r1")
+
+	// the Behavior is validated for the readers before they're moved
+	err := rgs.create(ds, groupSpec{Name: "Dock", Readers: []string{"r1"}, Behavior: &llrp.Behavior{}})
+	assert.ErrorIs(t, err, errInvalidGroup)
+	assert.ErrorIs(t, err, llrp.ErrUnsatisfiable)
+	assert.Empty(t, td.sent("r1"))
+
+	_, ok := rgs.group("Dock")
+	assert.False(t, ok)
+	assert.Empty(t, namedSpecs(t, rgs))
+	assert.Equal(t, []string{"r1"}, members(t, rgs, defaultGroup))
+
+	assert.ErrorIs(t, rgs.create(ds, groupSpec{Name: defaultGroup}), errInvalidGroup)
+	assert.ErrorIs(t, rgs.create(ds, groupSpec{}), errInvalidGroup)
+}
+
+func TestReaderGroups_moveFails(t *testing.T) {
+	td, ds := newTestDevices(t)
+	rgs := newTestGroups(t, ds, map[string][]string{"r1": nil, "r2": nil})
+	defaultGrp, ok := rgs.group(defaultGroup)
+	require.True(t, ok)
+	require.NoError(t, defaultGrp.StartAll(ds))
+
+	// r1 moves first, so it's returned to its group when r2 fails to move
+	td.failNextCommand("r2")
+	err := rgs.create(ds, groupSpec{Name: "Dock", Readers: []string{"r1", "r2"}})
+	require.Error(t, err)
+	_, ok = rgs.group("Dock")
+	assert.False(t, ok)
+	assert.Empty(t, namedSpecs(t, rgs))
+	assert.Equal(t, []string{"r1", "r2"}, members(t, rgs, defaultGroup))
+	assert.Contains(t, td.sent("r1"), "enableROSpec", "readers returned to a reading group are restarted")
+	assert.Contains(t, td.sent("r2"), "enableROSpec", "readers returned to a reading group are restarted")
+
+	// nor are the group's readers replaced if they can't be moved
+	require.NoError(t, rgs.create(ds, groupSpec{Name: "Dock", Readers: []string{"r1"}}))
+	td.failNextCommand("r2")
+	require.Error(t, rgs.update(ds, groupSpec{Name: "Dock", Readers: []string{"r2"}}))
+	assert.Equal(t, []string{"r1"}, members(t, rgs, "Dock"))
+	assert.Equal(t, []string{"r2"}, members(t, rgs, defaultGroup))
+	specs := namedSpecs(t, rgs)
+	require.Len(t, specs, 1)
+	assert.Equal(t, []string{"r1"}, specs[0].Readers)
+
+	// nor is the group deleted
+	td.failNextCommand("r1")
+	require.Error(t, rgs.delete(ds, "Dock"))
+	assert.Equal(t, []string{"r1"}, members(t, rgs, "Dock"))
+}
+
+func TestReaderGroups_gpis(t *testing.T) {
+	td, ds := newTestDevices(t)
+	rgs := newTestGroups(t, ds, map[string][]string{"r1": nil, "r2": nil})
+	td.sentConfigs("r1")
+	td.sentConfigs("r2")
+
+	gpiConfig := &llrp.SetReaderConfig{
+		ReaderEventNotificationSpec: &llrp.ReaderEventNotificationSpec{
+			EventNotificationStates: []llrp.EventNotificationState{
+				{ReaderEventType: llrp.NotifyGPI, NotificationEnabled: true},
+			},
+		},
+		GPIPortCurrentStates: []llrp.GPIPortCurrentState{{Port: 1, Enabled: true, State: llrp.GPIStateUnknown}},
+	}
+
+	rgs.setGPIs(ds, map[string][]uint16{"r1": {1}})
+	assert.Equal(t, []llrp.SetReaderConfig{*gpiConfig}, td.sentConfigs("r1"))
+	assert.Empty(t, td.sentConfigs("r2"))
+
+	// unchanged ports aren't sent again
+	rgs.setGPIs(ds, map[string][]uint16{"r1": {1}})
+	assert.Empty(t, td.sentConfigs("r1"))
+
+	// adding the reader again resets its config, so its GPIs are enabled after that
+	_, err := rgs.addReader(ds, "r1", nil)
+	require.NoError(t, err)
+	configs := td.sentConfigs("r1")
+	require.Len(t, configs, 2)
+	assert.True(t, configs[0].ResetToFactoryDefaults)
+	assert.Equal(t, *gpiConfig, configs[1])
+
+	// as does moving it to another group
+	require.NoError(t, rgs.create(ds, groupSpec{Name: "Dock", Readers: []string{"r1"}}))
+	configs = td.sentConfigs("r1")
+	require.Len(t, configs, 2)
+	assert.True(t, configs[0].ResetToFactoryDefaults)
+	assert.Equal(t, *gpiConfig, configs[1])
+
+	// the reader has only 1 GPI, but it's still usable
+	rgs.setGPIs(ds, map[string][]uint16{"r1": {2}})
+	assert.Empty(t, td.sentConfigs("r1"))
+	assert.Equal(t, []string{"r1"}, members(t, rgs, "Dock"))
+}
+
+func TestGroups_persistence(t *testing.T) {
+	app := newTestApp(t)
+	defaultBehavior := llrp.Behavior{ScanType: llrp.ScanFast, Power: llrp.PowerTarget{Max: 3000}}
+	dockBehavior := llrp.Behavior{ScanType: llrp.ScanDeep, Power: llrp.PowerTarget{Max: 2000}}
+	require.NoError(t, app.groups.create(app.devService, groupSpec{Name: "Dock", Labels: []string{"dock"}}))
+	require.NoError(t, app.setGroupBehavior("Dock", dockBehavior))
+	require.NoError(t, app.setGroupBehavior(defaultGroup, defaultBehavior))
+
+	// both the named and default groups' Behaviors survive a restart
+	restarted := NewInventoryApp()
+	restarted.lc = app.lc
+	restarted.groups = newReaderGroups(restarted.lc)
+	restarted.loadGroups()
+
+	grp, ok := restarted.groups.group(defaultGroup)
+	require.True(t, ok)
+	assert.Equal(t, defaultBehavior, grp.Behavior())
+	grp, ok = restarted.groups.group("Dock")
+	require.True(t, ok)
+	assert.Equal(t, dockBehavior, grp.Behavior())
+	info, ok := restarted.groups.info("Dock")
+	require.True(t, ok)
+	assert.Equal(t, []string{"dock"}, info.Labels)
+}
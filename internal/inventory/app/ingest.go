@@ -0,0 +1,325 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"sync"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/inventory"
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+const defaultReportQueueSize = 100
+
+// reportQueue is a bounded queue of tag reports waiting for the taskLoop,
+// which decouples the SDK pipeline from the inventory processing.
+//
+// Reports are queued per device and dequeued round-robin across devices,
+// so a device that sends many reports can't delay the others' reports.
+// When the queue is full, new reports are handled according to its policy:
+//   - Block waits until there's room, as the pipeline did before the queue existed.
+//   - DropOldest drops the oldest report of the device with the most queued reports.
+//   - Coalesce merges the report into the device's most recently queued report,
+//     and if the device has none, drops as DropOldest does.
+//
+// Reports with tag access results are never dropped or coalesced,
+// since requests to write, lock, or kill tags wait for them;
+// if only such reports are queued, the queue exceeds its capacity rather than dropping one.
+type reportQueue struct {
+	lc logger.LoggingClient
+
+	mu       sync.Mutex
+	hasRoom  *sync.Cond
+	policy   string
+	capacity int
+	closed   bool
+
+	depth   int
+	order   []string // devices with queued reports, in the order they're served
+	pending map[string][]queuedReport
+	stats   ingestStats
+
+	// ready is signaled when reports are available.
+	ready chan struct{}
+}
+
+// queuedReport is a report along with the time it was queued.
+type queuedReport struct {
+	reportData
+	queued time.Time
+	// access is true if the report has tag access results.
+	access bool
+}
+
+// hasAccessResults returns true if the report has results of tag access operations,
+// other than memory reads.
+func hasAccessResults(r *llrp.ROAccessReport) bool {
+	for i := range r.TagReportData {
+		if len(r.TagReportData[i].AccessResults()) != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ingestStats are the report queue's status and counters reported via the REST API.
+type ingestStats struct {
+	Policy   string `json:"policy"`
+	Capacity int    `json:"capacity"`
+	Depth    int    `json:"depth"`
+	deviceIngestStats
+	Processed uint64 `json:"processed"`
+	// LatencyMillis describes how long reports wait in the queue before they're processed.
+	LatencyMillis latencyStats                  `json:"latencyMillis"`
+	Devices       map[string]*deviceIngestStats `json:"devices"`
+}
+
+// deviceIngestStats are the counters for reports from a single device.
+type deviceIngestStats struct {
+	Received uint64 `json:"received"`
+	// Dropped counts reports discarded because the queue was full.
+	Dropped uint64 `json:"dropped"`
+	// Coalesced counts reports merged into a queued report from the same device.
+	Coalesced uint64 `json:"coalesced"`
+}
+
+// latencyStats summarize the time reports spend in the queue.
+type latencyStats struct {
+	Last  int64   `json:"last"`
+	Max   int64   `json:"max"`
+	Mean  float64 `json:"mean"`
+	total int64
+}
+
+func newReportQueue(lc logger.LoggingClient, policy string, capacity uint) *reportQueue {
+	q := &reportQueue{
+		lc:      lc,
+		pending: make(map[string][]queuedReport),
+		ready:   make(chan struct{}, 1),
+	}
+	q.hasRoom = sync.NewCond(&q.mu)
+	q.stats.Devices = make(map[string]*deviceIngestStats)
+	q.setLimits(policy, capacity)
+	return q
+}
+
+// setLimits updates the queue's policy and capacity.
+// An empty policy means Block, and a 0 capacity means the default.
+// Reports already queued beyond a reduced capacity are kept.
+func (q *reportQueue) setLimits(policy string, capacity uint) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if policy == "" {
+		policy = inventory.ReportQueueBlock
+	}
+	if capacity == 0 {
+		capacity = defaultReportQueueSize
+	}
+	q.policy = policy
+	q.capacity = int(capacity) // #nosec G115
+	q.hasRoom.Broadcast()
+}
+
+// close discards any queued reports and releases any blocked producers.
+// Reports pushed after the queue is closed are dropped.
+func (q *reportQueue) close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.closed = true
+	q.depth = 0
+	q.order = nil
+	q.pending = make(map[string][]queuedReport)
+	q.hasRoom.Broadcast()
+}
+
+// signal notifies the consumer that reports are available, without blocking.
+func (q *reportQueue) signal() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (q *reportQueue) deviceStats(device string) *deviceIngestStats {
+	ds, ok := q.stats.Devices[device]
+	if !ok {
+		ds = &deviceIngestStats{}
+		q.stats.Devices[device] = ds
+	}
+	return ds
+}
+
+// push adds a report to the queue, applying the policy if it's full.
+func (q *reportQueue) push(rd reportData) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	device := rd.info.DeviceName
+	ds := q.deviceStats(device)
+	ds.Received++
+	q.stats.Received++
+
+	for !q.closed && q.depth >= q.capacity && q.policy == inventory.ReportQueueBlock {
+		q.hasRoom.Wait()
+	}
+
+	if q.closed {
+		ds.Dropped++
+		q.stats.Dropped++
+		return
+	}
+
+	access := hasAccessResults(rd.report)
+	if q.depth >= q.capacity {
+		if q.policy == inventory.ReportQueueCoalesce && !access && q.coalesce(rd) {
+			ds.Coalesced++
+			q.stats.Coalesced++
+			q.signal()
+			return
+		}
+		if !q.dropOldest() && !access {
+			// only reports with access results are queued, so this one is dropped instead
+			ds.Dropped++
+			q.stats.Dropped++
+			q.lc.Warn("Report queue is full of access results; dropped new report.", "device", device)
+			return
+		}
+	}
+
+	if len(q.pending[device]) == 0 {
+		q.order = append(q.order, device)
+	}
+	q.pending[device] = append(q.pending[device], queuedReport{reportData: rd, queued: time.Now(), access: access})
+	q.depth++
+	q.signal()
+}
+
+// coalesce merges the report's tag data and custom parameters into the device's most recently queued report,
+// and returns false if the device has no queued reports, or that report has access results.
+//
+// The merged report takes the newer report's info, since the inventory adjusts
+// the read timestamps of a report relative to its most recent read.
+func (q *reportQueue) coalesce(rd reportData) bool {
+	queued := q.pending[rd.info.DeviceName]
+	if len(queued) == 0 || queued[len(queued)-1].access {
+		return false
+	}
+
+	last := &queued[len(queued)-1]
+	merged := *last.report
+	n := len(merged.TagReportData)
+	// limit the capacity so the append copies rather than modifying the original report
+	merged.TagReportData = append(merged.TagReportData[:n:n], rd.report.TagReportData...)
+	n = len(merged.Custom)
+	merged.Custom = append(merged.Custom[:n:n], rd.report.Custom...)
+	last.report = &merged
+	last.info = rd.info
+	return true
+}
+
+// dropOldest drops the oldest report without access results
+// of the device with the most queued reports that has such a report,
+// and returns false if there's no such report.
+func (q *reportQueue) dropOldest() bool {
+	var noisiest string
+	oldest := -1
+	for _, device := range q.order {
+		if len(q.pending[device]) <= len(q.pending[noisiest]) {
+			continue
+		}
+		for i, qr := range q.pending[device] {
+			if !qr.access {
+				noisiest, oldest = device, i
+				break
+			}
+		}
+	}
+	if oldest < 0 {
+		return false
+	}
+
+	queued := q.pending[noisiest]
+	q.pending[noisiest] = append(queued[:oldest:oldest], queued[oldest+1:]...)
+	q.depth--
+	q.deviceStats(noisiest).Dropped++
+	q.stats.Dropped++
+	q.lc.Warn("Report queue is full; dropped oldest report.", "device", noisiest)
+
+	if len(q.pending[noisiest]) == 0 {
+		q.removeFromOrder(noisiest)
+	}
+	return true
+}
+
+func (q *reportQueue) removeFromOrder(device string) {
+	delete(q.pending, device)
+	for i, d := range q.order {
+		if d == device {
+			q.order = append(q.order[:i], q.order[i+1:]...)
+			return
+		}
+	}
+}
+
+// pop returns the next report, taking one from each device in turn,
+// or false if the queue is empty.
+// If reports remain after this one, it signals ready again.
+func (q *reportQueue) pop() (reportData, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.depth == 0 {
+		return reportData{}, false
+	}
+
+	device := q.order[0]
+	q.order = q.order[1:]
+	next := q.pending[device][0]
+	q.pending[device] = q.pending[device][1:]
+	if len(q.pending[device]) == 0 {
+		delete(q.pending, device)
+	} else {
+		q.order = append(q.order, device)
+	}
+	q.depth--
+
+	latency := time.Since(next.queued).Milliseconds()
+	q.stats.Processed++
+	q.stats.LatencyMillis.Last = latency
+	q.stats.LatencyMillis.Max = max(q.stats.LatencyMillis.Max, latency)
+	q.stats.LatencyMillis.total += latency
+
+	q.hasRoom.Signal()
+	if q.depth > 0 {
+		q.signal()
+	}
+	return next.reportData, true
+}
+
+// snapshot returns a copy of the current status and counters.
+func (q *reportQueue) snapshot() ingestStats {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	s := q.stats
+	s.Policy = q.policy
+	s.Capacity = q.capacity
+	s.Depth = q.depth
+	if s.Processed > 0 {
+		s.LatencyMillis.Mean = float64(s.LatencyMillis.total) / float64(s.Processed)
+	}
+	s.Devices = make(map[string]*deviceIngestStats, len(q.stats.Devices))
+	for device, ds := range q.stats.Devices {
+		c := *ds
+		s.Devices[device] = &c
+	}
+	return s
+}
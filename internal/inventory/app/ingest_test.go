@@ -0,0 +1,180 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"testing"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/inventory"
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testReport returns a report of a single tag from the device,
+// which has a kill result if access is true.
+func testReport(device string, epc byte, access bool) reportData {
+	rt := llrp.TagReportData{EPC96: llrp.EPC96{EPC: []byte{epc}}}
+	if access {
+		rt.C1G2KillOpSpecResult = &llrp.C1G2KillOpSpecResult{OpSpecID: accessOpSpecIDBase}
+	}
+	return reportData{
+		report: &llrp.ROAccessReport{TagReportData: []llrp.TagReportData{rt}},
+		info:   inventory.ReportInfo{DeviceName: device},
+	}
+}
+
+// popEPCs pops every queued report and returns their devices and EPCs, in order.
+func popEPCs(q *reportQueue) (devices []string, epcs [][]byte) {
+	for {
+		rd, ok := q.pop()
+		if !ok {
+			return devices, epcs
+		}
+		devices = append(devices, rd.info.DeviceName)
+		for _, rt := range rd.report.TagReportData {
+			epcs = append(epcs, rt.EPC96.EPC)
+		}
+	}
+}
+
+func TestReportQueue_roundRobin(t *testing.T) {
+	q := newReportQueue(logger.NewMockClient(), inventory.ReportQueueDropOldest, 10)
+	q.push(testReport("a", 1, false))
+	q.push(testReport("a", 2, false))
+	q.push(testReport("a", 3, false))
+	q.push(testReport("b", 4, false))
+
+	devices, epcs := popEPCs(q)
+	assert.Equal(t, []string{"a", "b", "a", "a"}, devices)
+	assert.Equal(t, [][]byte{{1}, {4}, {2}, {3}}, epcs)
+
+	s := q.snapshot()
+	assert.Equal(t, uint64(4), s.Received)
+	assert.Equal(t, uint64(4), s.Processed)
+	assert.Zero(t, s.Depth)
+}
+
+func TestReportQueue_block(t *testing.T) {
+	// reports are never dropped unless a policy is chosen that drops them
+	q := newReportQueue(logger.NewMockClient(), "", 1)
+	require.Equal(t, inventory.ReportQueueBlock, q.policy)
+	q.push(testReport("a", 1, false))
+
+	pushed := make(chan struct{})
+	go func() {
+		q.push(testReport("a", 2, false))
+		close(pushed)
+	}()
+
+	select {
+	case <-pushed:
+		t.Fatal("push should block while the queue is full")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	_, ok := q.pop()
+	require.True(t, ok)
+	select {
+	case <-pushed:
+	case <-time.After(time.Second):
+		t.Fatal("push should resume once there's room")
+	}
+	_, epcs := popEPCs(q)
+	assert.Equal(t, [][]byte{{2}}, epcs)
+
+	// closing the queue releases blocked producers
+	q.push(testReport("a", 3, false))
+	released := make(chan struct{})
+	go func() {
+		q.push(testReport("a", 4, false))
+		close(released)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.close()
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("closing the queue should release blocked producers")
+	}
+	_, ok = q.pop()
+	assert.False(t, ok)
+}
+
+func TestReportQueue_dropOldest(t *testing.T) {
+	q := newReportQueue(logger.NewMockClient(), inventory.ReportQueueDropOldest, 3)
+	q.push(testReport("a", 1, false))
+	q.push(testReport("a", 2, false))
+	q.push(testReport("b", 3, false))
+	q.push(testReport("b", 4, false))
+
+	s := q.snapshot()
+	assert.Equal(t, 3, s.Depth)
+	assert.Equal(t, uint64(1), s.Dropped)
+	assert.Equal(t, uint64(1), s.Devices["a"].Dropped, "the noisiest device's oldest report is dropped")
+
+	_, epcs := popEPCs(q)
+	assert.Equal(t, [][]byte{{2}, {3}, {4}}, epcs)
+}
+
+func TestReportQueue_coalesce(t *testing.T) {
+	q := newReportQueue(logger.NewMockClient(), inventory.ReportQueueCoalesce, 2)
+	q.push(testReport("a", 1, false))
+	q.push(testReport("a", 2, false))
+	q.push(testReport("a", 3, false))
+
+	s := q.snapshot()
+	assert.Equal(t, 2, s.Depth)
+	assert.Equal(t, uint64(1), s.Coalesced)
+
+	// a device without queued reports can't coalesce, so the oldest report is dropped
+	q.push(testReport("b", 4, false))
+	s = q.snapshot()
+	assert.Equal(t, uint64(1), s.Devices["a"].Dropped)
+
+	devices, epcs := popEPCs(q)
+	assert.Equal(t, []string{"a", "b"}, devices)
+	assert.Equal(t, [][]byte{{2}, {3}, {4}}, epcs)
+}
+
+func TestReportQueue_accessResults(t *testing.T) {
+	for _, policy := range []string{inventory.ReportQueueDropOldest, inventory.ReportQueueCoalesce} {
+		t.Run(policy, func(t *testing.T) {
+			q := newReportQueue(logger.NewMockClient(), policy, 1)
+			q.push(testReport("a", 1, true))
+
+			// reports with access results aren't dropped or coalesced to make room
+			q.push(testReport("a", 2, true))
+			assert.Equal(t, 2, q.snapshot().Depth)
+
+			// nor are other reports coalesced into them
+			q.push(testReport("a", 3, false))
+			s := q.snapshot()
+			assert.Equal(t, 2, s.Depth)
+			assert.Zero(t, s.Coalesced)
+			assert.Equal(t, uint64(1), s.Dropped, "the new report is dropped instead")
+
+			_, epcs := popEPCs(q)
+			assert.Equal(t, [][]byte{{1}, {2}}, epcs)
+
+			// other reports are dropped before them, even if their device is less noisy
+			q.push(testReport("a", 4, true))
+			q.push(testReport("a", 5, true))
+			q.push(testReport("b", 6, false))
+			q.push(testReport("c", 7, true))
+			s = q.snapshot()
+			assert.Equal(t, 3, s.Depth)
+			assert.Equal(t, uint64(1), s.Devices["b"].Dropped)
+			assert.Zero(t, s.Coalesced)
+
+			_, epcs = popEPCs(q)
+			assert.Equal(t, [][]byte{{4}, {7}, {5}}, epcs)
+		})
+	}
+}
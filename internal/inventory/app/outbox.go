@@ -0,0 +1,285 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+const (
+	outboxFolder  = "outbox"
+	outboxFileExt = ".json"
+
+	minPublishRetry = 1 * time.Second
+)
+
+// outbox is a disk-backed FIFO of event payloads waiting to be published.
+//
+// Each payload is stored in its own file, named by its sequence number,
+// before any attempt to publish it, and the file is removed once it's published.
+// Payloads still in the outbox when the service stops are replayed when it restarts.
+type outbox struct {
+	lc  logger.LoggingClient
+	dir string
+
+	mu         sync.Mutex
+	entries    []outboxEntry
+	nextSeq    uint64
+	maxEntries int
+	maxRetry   time.Duration
+	dropped    uint64
+	lastErr    string
+
+	// ready is signaled when entries are added.
+	ready chan struct{}
+}
+
+// outboxEntry is a single payload in the outbox.
+type outboxEntry struct {
+	seq     uint64
+	created time.Time
+	// payload is only kept in memory if it couldn't be written to disk.
+	payload []byte
+}
+
+// outboxStats is the outbox status reported via the REST API.
+type outboxStats struct {
+	// Depth is the number of event payloads waiting to be published.
+	Depth int `json:"depth"`
+	// OldestAgeMillis is the age of the oldest waiting payload, or 0 if there are none.
+	OldestAgeMillis int64 `json:"oldestAgeMillis"`
+	// Dropped is the number of payloads discarded because the outbox was full.
+	Dropped uint64 `json:"dropped"`
+	// LastError is the most recent publishing error, if the last attempt failed.
+	LastError string `json:"lastError,omitempty"`
+}
+
+// openOutbox returns an outbox stored in dir, creating dir if necessary,
+// and loading any entries that were left in it.
+func openOutbox(lc logger.LoggingClient, dir string, maxEntries uint, maxRetrySeconds uint) (*outbox, error) {
+	ob := &outbox{
+		lc:    lc,
+		dir:   dir,
+		ready: make(chan struct{}, 1),
+	}
+	ob.setLimits(maxEntries, maxRetrySeconds)
+
+	if err := os.MkdirAll(dir, folderPerm); err != nil {
+		return ob, fmt.Errorf("failed to create outbox directory %q: %w", dir, err)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		return ob, fmt.Errorf("failed to read outbox directory %q: %w", dir, err)
+	}
+
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || !strings.HasSuffix(name, outboxFileExt) {
+			continue
+		}
+
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, outboxFileExt), 10, 64)
+		if err != nil {
+			lc.Warn("Ignoring unexpected file in outbox.", "file", name)
+			continue
+		}
+
+		created := time.Now()
+		if info, err := f.Info(); err == nil {
+			created = info.ModTime()
+		}
+
+		ob.entries = append(ob.entries, outboxEntry{seq: seq, created: created})
+		if seq >= ob.nextSeq {
+			ob.nextSeq = seq + 1
+		}
+	}
+
+	sort.Slice(ob.entries, func(i, j int) bool { return ob.entries[i].seq < ob.entries[j].seq })
+	if len(ob.entries) > 0 {
+		ob.signal()
+	}
+	return ob, nil
+}
+
+// setLimits updates the maximum number of entries, where 0 means unlimited,
+// and the maximum delay between publishing attempts.
+func (ob *outbox) setLimits(maxEntries uint, maxRetrySeconds uint) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.maxEntries = int(maxEntries) // #nosec G115
+	ob.maxRetry = max(time.Duration(maxRetrySeconds)*time.Second, minPublishRetry)
+}
+
+// path returns the path of the file which holds the entry with the given sequence number.
+func (ob *outbox) path(seq uint64) string {
+	return filepath.Join(ob.dir, fmt.Sprintf("%020d%s", seq, outboxFileExt))
+}
+
+// signal notifies the sender that entries are available, without blocking.
+func (ob *outbox) signal() {
+	select {
+	case ob.ready <- struct{}{}:
+	default:
+	}
+}
+
+// add appends a payload to the outbox.
+//
+// If the payload can't be written to disk, it's kept in memory instead,
+// so it isn't lost unless the service stops before it's published.
+// If the outbox is full, the oldest entries are discarded to make room.
+func (ob *outbox) add(payload []byte) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	e := outboxEntry{seq: ob.nextSeq, created: time.Now()}
+	ob.nextSeq++
+
+	if err := os.WriteFile(ob.path(e.seq), payload, filePerm); err != nil {
+		ob.lc.Error("Failed to write events to outbox; keeping them in memory.", "error", err.Error())
+		e.payload = payload
+	}
+
+	ob.entries = append(ob.entries, e)
+	if over := len(ob.entries) - ob.maxEntries; ob.maxEntries > 0 && over > 0 {
+		for _, old := range ob.entries[:over] {
+			ob.removeFile(old)
+		}
+		ob.entries = append(ob.entries[:0], ob.entries[over:]...)
+		ob.dropped += uint64(over) // #nosec G115
+		ob.lc.Warn("Outbox is full; dropped oldest events.", "dropped", over, "maxEntries", ob.maxEntries)
+	}
+
+	ob.signal()
+}
+
+// removeFile deletes the entry's file, if it has one.
+// It must be called while holding the lock.
+func (ob *outbox) removeFile(e outboxEntry) {
+	if e.payload != nil {
+		return
+	}
+	if err := os.Remove(ob.path(e.seq)); err != nil && !os.IsNotExist(err) {
+		ob.lc.Warn("Failed to remove outbox entry.", "file", ob.path(e.seq), "error", err.Error())
+	}
+}
+
+// peek returns the oldest entry and its payload, if there are any entries.
+// Entries whose payload can't be read are discarded.
+func (ob *outbox) peek() (outboxEntry, []byte, bool) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	for len(ob.entries) > 0 {
+		e := ob.entries[0]
+		if e.payload != nil {
+			return e, e.payload, true
+		}
+
+		payload, err := os.ReadFile(ob.path(e.seq))
+		if err == nil {
+			return e, payload, true
+		}
+
+		ob.lc.Error("Discarding unreadable outbox entry.", "file", ob.path(e.seq), "error", err.Error())
+		ob.removeFile(e)
+		ob.entries = ob.entries[1:]
+	}
+
+	return outboxEntry{}, nil, false
+}
+
+// remove deletes the entry after it's been published.
+// It does nothing if the entry was already dropped.
+func (ob *outbox) remove(e outboxEntry) {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.lastErr = ""
+	if len(ob.entries) == 0 || ob.entries[0].seq != e.seq {
+		return
+	}
+	ob.removeFile(e)
+	ob.entries = ob.entries[1:]
+}
+
+// setError records a failure to publish, and returns the maximum retry delay.
+func (ob *outbox) setError(err error) time.Duration {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	ob.lastErr = err.Error()
+	return ob.maxRetry
+}
+
+// stats returns the current outbox status.
+func (ob *outbox) stats() outboxStats {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+
+	s := outboxStats{
+		Depth:     len(ob.entries),
+		Dropped:   ob.dropped,
+		LastError: ob.lastErr,
+	}
+	if len(ob.entries) > 0 {
+		s.OldestAgeMillis = time.Since(ob.entries[0].created).Milliseconds()
+	}
+	return s
+}
+
+// run publishes entries in order until ctx is cancelled.
+//
+// When publishing fails, it retries the same entry with exponential backoff,
+// up to the outbox's maximum retry delay.
+// Any unpublished entries remain in the outbox when it returns.
+func (ob *outbox) run(ctx context.Context, publish func([]byte) error) {
+	retry := minPublishRetry
+	for {
+		e, payload, ok := ob.peek()
+		if !ok {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ob.ready:
+				continue
+			}
+		}
+
+		if err := publish(payload); err != nil {
+			maxRetry := ob.setError(err)
+			retry = min(retry, maxRetry)
+			ob.lc.Error("Failed to publish inventory events; will retry.",
+				"error", err.Error(), "retryIn", retry.String())
+
+			t := time.NewTimer(retry)
+			select {
+			case <-ctx.Done():
+				t.Stop()
+				return
+			case <-t.C:
+			}
+			retry = min(2*retry, maxRetry)
+			continue
+		}
+
+		ob.remove(e)
+		retry = minPublishRetry
+	}
+}
@@ -0,0 +1,160 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testPublisher records published payloads, failing while err is set.
+type testPublisher struct {
+	mu        sync.Mutex
+	err       error
+	published []string
+	attempts  int
+}
+
+func (tp *testPublisher) publish(payload []byte) error {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	tp.attempts++
+	if tp.err != nil {
+		return tp.err
+	}
+	tp.published = append(tp.published, string(payload))
+	return nil
+}
+
+func (tp *testPublisher) setErr(err error) {
+	tp.mu.Lock()
+	tp.err = err
+	tp.mu.Unlock()
+}
+
+func (tp *testPublisher) get() (published []string, attempts int) {
+	tp.mu.Lock()
+	defer tp.mu.Unlock()
+	return append([]string(nil), tp.published...), tp.attempts
+}
+
+func newTestOutbox(t *testing.T, dir string, maxEntries uint) *outbox {
+	t.Helper()
+	ob, err := openOutbox(logger.NewMockClient(), dir, maxEntries, 1)
+	require.NoError(t, err)
+	return ob
+}
+
+// runOutbox runs the outbox until the returned function is called.
+func runOutbox(ob *outbox, pub *testPublisher) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		ob.run(ctx, pub.publish)
+		close(done)
+	}()
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+func outboxFiles(t *testing.T, dir string) int {
+	t.Helper()
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	return len(files)
+}
+
+func TestOutbox_publishInOrder(t *testing.T) {
+	dir := t.TempDir()
+	ob := newTestOutbox(t, dir, 0)
+	ob.add([]byte("1"))
+	ob.add([]byte("2"))
+	assert.Equal(t, 2, outboxFiles(t, dir), "entries are stored before they're published")
+
+	pub := &testPublisher{}
+	stop := runOutbox(ob, pub)
+	defer stop()
+	ob.add([]byte("3"))
+
+	require.Eventually(t, func() bool { return ob.stats().Depth == 0 }, time.Second, 5*time.Millisecond)
+	published, _ := pub.get()
+	assert.Equal(t, []string{"1", "2", "3"}, published)
+	assert.Zero(t, outboxFiles(t, dir))
+}
+
+func TestOutbox_retry(t *testing.T) {
+	dir := t.TempDir()
+	ob := newTestOutbox(t, dir, 0)
+	pub := &testPublisher{err: errors.New("bus unavailable")}
+	stop := runOutbox(ob, pub)
+	defer stop()
+
+	ob.add([]byte("1"))
+	require.Eventually(t, func() bool { return ob.stats().LastError != "" }, time.Second, 5*time.Millisecond)
+	stats := ob.stats()
+	assert.Equal(t, 1, stats.Depth, "entries stay in the outbox until they're published")
+	assert.Equal(t, "bus unavailable", stats.LastError)
+	assert.Equal(t, 1, outboxFiles(t, dir))
+
+	pub.setErr(nil)
+	require.Eventually(t, func() bool { return ob.stats().Depth == 0 }, 3*time.Second, 10*time.Millisecond)
+	published, attempts := pub.get()
+	assert.Equal(t, []string{"1"}, published)
+	assert.Equal(t, 2, attempts)
+	assert.Empty(t, ob.stats().LastError)
+	assert.Zero(t, outboxFiles(t, dir))
+}
+
+func TestOutbox_stopKeepsEntries(t *testing.T) {
+	dir := t.TempDir()
+	ob := newTestOutbox(t, dir, 0)
+	pub := &testPublisher{err: errors.New("bus unavailable")}
+	stop := runOutbox(ob, pub)
+
+	ob.add([]byte("1"))
+	ob.add([]byte("2"))
+	require.Eventually(t, func() bool { _, attempts := pub.get(); return attempts != 0 }, time.Second, 5*time.Millisecond)
+	stop()
+
+	// the unpublished entries are replayed after a restart
+	reopened := newTestOutbox(t, dir, 0)
+	assert.Equal(t, 2, reopened.stats().Depth)
+	pub.setErr(nil)
+	stop = runOutbox(reopened, pub)
+	defer stop()
+	reopened.add([]byte("3"))
+
+	require.Eventually(t, func() bool { return reopened.stats().Depth == 0 }, time.Second, 5*time.Millisecond)
+	published, _ := pub.get()
+	assert.Equal(t, []string{"1", "2", "3"}, published)
+}
+
+func TestOutbox_maxEntries(t *testing.T) {
+	dir := t.TempDir()
+	ob := newTestOutbox(t, dir, 2)
+	for _, p := range []string{"1", "2", "3"} {
+		ob.add([]byte(p))
+	}
+
+	stats := ob.stats()
+	assert.Equal(t, 2, stats.Depth)
+	assert.Equal(t, uint64(1), stats.Dropped)
+	assert.Equal(t, 2, outboxFiles(t, dir))
+
+	_, payload, ok := ob.peek()
+	require.True(t, ok)
+	assert.Equal(t, "2", string(payload), "the oldest entries are dropped")
+}
@@ -0,0 +1,254 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"sync"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/inventory"
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+)
+
+// gpoPort identifies a GPO port of a reader.
+type gpoPort struct {
+	device string
+	port   uint16
+}
+
+// gpoRequest is an element of the body of a request to set a reader's GPO ports.
+type gpoRequest struct {
+	Port  uint16 `json:"port"`
+	State bool   `json:"state"`
+	// Duration, if non-zero, is how long the port holds the State
+	// before it's set back to its opposite.
+	Duration llrp.Millisecs32 `json:"duration,omitempty"`
+}
+
+// maxQueuedFirings is the number of OutputRule firings waiting to set their ports
+// beyond which further firings are dropped.
+const maxQueuedFirings = 64
+
+// firing is an OutputRule that fired, waiting to set its port.
+type firing struct {
+	rule string
+	port gpoPort
+	req  gpoRequest
+}
+
+// outputs sets readers' GPO ports, both on request and according to the OutputRules,
+// and sets them back once their durations expire.
+type outputs struct {
+	lc    logger.LoggingClient
+	write func(device string, writes []llrp.GPOWriteData) error
+	// firings are set in order by run, so rules firing in a burst don't pile up goroutines.
+	firings chan firing
+
+	mu      sync.Mutex
+	rules   []inventory.OutputRule
+	aliases map[string]string
+	// ports are the states of the GPO ports that have been set.
+	ports map[gpoPort]*portState
+}
+
+// portState serializes the writes to a GPO port.
+type portState struct {
+	mu sync.Mutex
+	// gen increments each time the port is set,
+	// so a reset only writes the port if it hasn't been set since the reset was scheduled.
+	gen uint64
+	// reset will set the port back to its opposite state.
+	reset *time.Timer
+}
+
+func newOutputs(lc logger.LoggingClient, write func(device string, writes []llrp.GPOWriteData) error) *outputs {
+	return &outputs{
+		lc:      lc,
+		write:   write,
+		firings: make(chan firing, maxQueuedFirings),
+		ports:   make(map[gpoPort]*portState),
+	}
+}
+
+// setRules replaces the OutputRules,
+// along with the aliases used to match their locations.
+func (o *outputs) setRules(rules []inventory.OutputRule, aliases map[string]string) {
+	o.mu.Lock()
+	o.rules, o.aliases = rules, aliases
+	o.mu.Unlock()
+}
+
+// handle fires the OutputRules matching the events.
+// It doesn't wait for the ports to be set,
+// and drops the firings if too many are already waiting.
+func (o *outputs) handle(events []inventory.Event) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for _, rule := range o.rules {
+		for _, e := range events {
+			if !rule.Matches(e, o.aliases) {
+				continue
+			}
+
+			o.lc.Debug("Output rule fired.", "rule", rule.Name, "event", string(e.OfType()))
+			f := firing{
+				rule: rule.Name,
+				port: gpoPort{device: rule.Device, port: rule.Port},
+				req:  gpoRequest{Port: rule.Port, State: rule.State, Duration: rule.Duration},
+			}
+			select {
+			case o.firings <- f:
+			default:
+				o.lc.Warn("Too many output rule firings are waiting; dropping this one.",
+					"rule", rule.Name, "device", rule.Device, "port", rule.Port)
+			}
+			break // the rule only needs to fire once for the events
+		}
+	}
+}
+
+// run sets the ports of the OutputRules' firings until ctx is cancelled.
+func (o *outputs) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case f := <-o.firings:
+			if err := o.set(f.port.device, []gpoRequest{f.req}); err != nil {
+				o.lc.Error("Failed to set GPO port for output rule.",
+					"rule", f.rule, "device", f.port.device, "port", f.port.port, "error", err.Error())
+			}
+		}
+	}
+}
+
+// set writes the device's GPO ports together,
+// and for those with a non-zero duration, schedules them to be set back to the opposite state.
+// It cancels any previously scheduled resets of the ports,
+// so they hold their states for at least the new durations.
+//
+// Writes to the same port are serialized,
+// so a reset never overwrites a state set after it was scheduled.
+func (o *outputs) set(device string, reqs []gpoRequest) error {
+	states := o.lock(device, reqs)
+	defer func() {
+		for _, ps := range states {
+			ps.mu.Unlock()
+		}
+	}()
+
+	writes := make([]llrp.GPOWriteData, len(reqs))
+	for i, req := range reqs {
+		writes[i] = llrp.GPOWriteData{Port: req.Port, Data: req.State}
+	}
+	if err := o.write(device, writes); err != nil {
+		// the ports keep their pending resets, if any
+		return err
+	}
+
+	for _, ps := range states {
+		ps.gen++
+		if ps.reset != nil {
+			ps.reset.Stop()
+			ps.reset = nil
+		}
+	}
+	for _, req := range reqs {
+		if req.Duration != 0 {
+			o.scheduleReset(gpoPort{device: device, port: req.Port}, states[req.Port], !req.State, req.Duration)
+		}
+	}
+	return nil
+}
+
+// lock locks and returns the states of the device's requested ports,
+// in order of their port numbers, so concurrent calls can't deadlock.
+func (o *outputs) lock(device string, reqs []gpoRequest) map[uint16]*portState {
+	ports := make([]uint16, 0, len(reqs))
+	for _, req := range reqs {
+		ports = append(ports, req.Port)
+	}
+	slices.Sort(ports)
+	ports = slices.Compact(ports)
+
+	states := make(map[uint16]*portState, len(ports))
+	o.mu.Lock()
+	for _, port := range ports {
+		p := gpoPort{device: device, port: port}
+		ps, ok := o.ports[p]
+		if !ok {
+			ps = &portState{}
+			o.ports[p] = ps
+		}
+		states[port] = ps
+	}
+	o.mu.Unlock()
+
+	for _, port := range ports {
+		states[port].mu.Lock()
+	}
+	return states
+}
+
+// scheduleReset sets the GPO port to the state once the duration expires,
+// unless the port is set again in the meantime.
+// The port's state must be locked.
+func (o *outputs) scheduleReset(p gpoPort, ps *portState, state bool, duration llrp.Millisecs32) {
+	if ps.reset != nil {
+		ps.reset.Stop() // the request set the port more than once
+	}
+
+	gen := ps.gen
+	ps.reset = time.AfterFunc(time.Duration(duration)*time.Millisecond, func() {
+		ps.mu.Lock()
+		defer ps.mu.Unlock()
+		if ps.gen != gen {
+			return // the port was set since the reset was scheduled
+		}
+		ps.reset = nil
+
+		if err := o.write(p.device, []llrp.GPOWriteData{{Port: p.port, Data: state}}); err != nil {
+			o.lc.Error("Failed to reset GPO port.", "device", p.device, "port", p.port, "error", err.Error())
+		}
+	})
+}
+
+// writeGPO sets the GPO ports of the named reader.
+func (app *InventoryApp) writeGPO(device string, writes []llrp.GPOWriteData) error {
+	grp, ok := app.groups.groupOf(device)
+	if !ok {
+		return fmt.Errorf("%w: %q", errUnknownReader, device)
+	}
+	return grp.WriteGPO(app.devService, device, writes)
+}
+
+// setGPO handles a request to set the named reader's GPO ports.
+// Ports with a duration are set back to their opposite state once it expires.
+func (app *InventoryApp) setGPO(device string, reqs []gpoRequest) error {
+	if len(reqs) == 0 {
+		return fmt.Errorf("no GPO ports to set: %w", llrp.ErrInvalidGPO)
+	}
+	return app.outputs.set(device, reqs)
+}
+
+// updateOutputRules replaces the OutputRules with the configured rules,
+// unless they're invalid.
+func (app *InventoryApp) updateOutputRules(cfg inventory.CustomConfig) {
+	if err := inventory.ValidateOutputRules(cfg.OutputRules); err != nil {
+		app.lc.Error("Invalid output rules in configuration; keeping the current rules.",
+			"error", err.Error())
+		return
+	}
+	// the aliases are copied since the tag processor modifies its map
+	app.outputs.setRules(cfg.OutputRules, maps.Clone(cfg.Aliases))
+}
@@ -0,0 +1,181 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/inventory"
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// gpoWrite is a write to a device's GPO ports.
+type gpoWrite struct {
+	device string
+	writes []llrp.GPOWriteData
+}
+
+// newTestOutputs returns outputs that send their writes to the returned channel.
+func newTestOutputs(t *testing.T) (*outputs, chan gpoWrite) {
+	t.Helper()
+	written := make(chan gpoWrite, 16)
+	o := newOutputs(logger.NewMockClient(), func(device string, writes []llrp.GPOWriteData) error {
+		written <- gpoWrite{device: device, writes: writes}
+		return nil
+	})
+	return o, written
+}
+
+func nextWrite(t *testing.T, written <-chan gpoWrite) gpoWrite {
+	t.Helper()
+	select {
+	case w := <-written:
+		return w
+	case <-time.After(time.Second):
+		t.Fatal("expected a GPO write")
+		return gpoWrite{}
+	}
+}
+
+func TestOutputs_ruleFiring(t *testing.T) {
+	o, written := newTestOutputs(t)
+	o.setRules([]inventory.OutputRule{
+		{Name: "dock", Event: inventory.DepartedType, Location: "Dock", Device: "r1", Port: 2, State: true},
+		{Name: "disabled", Event: inventory.DepartedType, Device: "r1", Port: 3, State: true, Disabled: true},
+	}, map[string]string{"Dock": "r1_0"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go o.run(ctx)
+
+	o.handle([]inventory.Event{
+		inventory.ArrivedEvent{BaseEvent: inventory.BaseEvent{EPC: "30"}, Location: "r1_0"},
+		inventory.DepartedEvent{BaseEvent: inventory.BaseEvent{EPC: "31"}, LastKnownLocation: "r2_0"},
+	})
+	o.handle([]inventory.Event{
+		inventory.DepartedEvent{BaseEvent: inventory.BaseEvent{EPC: "30"}, LastKnownLocation: "r1_0"},
+		inventory.DepartedEvent{BaseEvent: inventory.BaseEvent{EPC: "31"}, LastKnownLocation: "r1_0"},
+	})
+
+	// the rule fires once for the matching events
+	w := nextWrite(t, written)
+	assert.Equal(t, gpoWrite{device: "r1", writes: []llrp.GPOWriteData{{Port: 2, Data: true}}}, w)
+	select {
+	case w := <-written:
+		t.Fatalf("unexpected GPO write: %+v", w)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestOutputs_dropFirings(t *testing.T) {
+	o, _ := newTestOutputs(t)
+	o.setRules([]inventory.OutputRule{
+		{Name: "any", Event: inventory.ArrivedType, Device: "r1", Port: 1, State: true},
+	}, nil)
+
+	// without a worker setting them, firings beyond the limit are dropped rather than blocking
+	arrived := []inventory.Event{inventory.ArrivedEvent{Location: "r1_0"}}
+	for range maxQueuedFirings + 1 {
+		o.handle(arrived)
+	}
+	assert.Len(t, o.firings, maxQueuedFirings)
+}
+
+func TestOutputs_pulseReset(t *testing.T) {
+	o, written := newTestOutputs(t)
+
+	require.NoError(t, o.set("r1", []gpoRequest{{Port: 1, State: true, Duration: 20}}))
+	assert.Equal(t, []llrp.GPOWriteData{{Port: 1, Data: true}}, nextWrite(t, written).writes)
+	assert.Equal(t, []llrp.GPOWriteData{{Port: 1, Data: false}}, nextWrite(t, written).writes,
+		"the port is set back once the duration expires")
+
+	// setting the port again cancels its reset
+	require.NoError(t, o.set("r1", []gpoRequest{{Port: 1, State: true, Duration: 20}}))
+	require.NoError(t, o.set("r1", []gpoRequest{{Port: 1, State: true}}))
+	assert.Equal(t, []llrp.GPOWriteData{{Port: 1, Data: true}}, nextWrite(t, written).writes)
+	assert.Equal(t, []llrp.GPOWriteData{{Port: 1, Data: true}}, nextWrite(t, written).writes)
+	select {
+	case w := <-written:
+		t.Fatalf("the port was reset after it was set again: %+v", w)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// even if the reset is already due, it doesn't overwrite a state set while it waited for the port
+	require.NoError(t, o.set("r1", []gpoRequest{{Port: 1, State: true, Duration: 1}}))
+	assert.Equal(t, []llrp.GPOWriteData{{Port: 1, Data: true}}, nextWrite(t, written).writes)
+	ps := o.ports[gpoPort{device: "r1", port: 1}]
+	ps.mu.Lock()
+	time.Sleep(20 * time.Millisecond)
+	ps.gen++ // as set does
+	ps.mu.Unlock()
+	select {
+	case w := <-written:
+		t.Fatalf("the port was reset after it was set again: %+v", w)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestOutputs_failedWrite(t *testing.T) {
+	written := make(chan gpoWrite, 16)
+	var fail atomic.Bool
+	o := newOutputs(logger.NewMockClient(), func(device string, writes []llrp.GPOWriteData) error {
+		if fail.Load() {
+			return errors.New("write failed")
+		}
+		written <- gpoWrite{device: device, writes: writes}
+		return nil
+	})
+
+	require.NoError(t, o.set("r1", []gpoRequest{{Port: 1, State: true, Duration: 50}}))
+	assert.Equal(t, []llrp.GPOWriteData{{Port: 1, Data: true}}, nextWrite(t, written).writes)
+
+	// a failed write leaves the pending reset in place
+	fail.Store(true)
+	require.Error(t, o.set("r1", []gpoRequest{{Port: 1, State: true}}))
+	fail.Store(false)
+	assert.Equal(t, []llrp.GPOWriteData{{Port: 1, Data: false}}, nextWrite(t, written).writes,
+		"the port is still set back once the duration expires")
+}
+
+func TestPostReaderGPO(t *testing.T) {
+	td, ds := newTestDevices(t)
+	app := newTestApp(t)
+	app.devService = ds
+	app.groups = newTestGroups(t, ds, map[string][]string{"r1": nil})
+	app.outputs = newOutputs(app.lc, app.writeGPO)
+	td.sent("r1")
+
+	post := func(device, body string) int {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+		rec := httptest.NewRecorder()
+		ctx := echo.New().NewContext(req, rec)
+		ctx.SetParamNames("name")
+		ctx.SetParamValues(device)
+		require.NoError(t, app.postReaderGPO(ctx))
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, post("r1", `[{"port": 1, "state": true}]`))
+	assert.NotEmpty(t, td.sent("r1"))
+
+	assert.Equal(t, http.StatusBadRequest, post("r1", `[{"port": 2, "state": true}]`), "the reader has 1 GPO")
+	assert.Equal(t, http.StatusBadRequest, post("r1", `[]`))
+	assert.Equal(t, http.StatusBadRequest, post("r1", `{`))
+	assert.Equal(t, http.StatusNotFound, post("r2", `[{"port": 1, "state": true}]`))
+	assert.Empty(t, td.sent("r1"))
+}
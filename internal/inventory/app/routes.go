@@ -7,23 +7,38 @@ package inventoryapp
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/labstack/echo/v4"
 	"io"
 	"net/http"
 
+	"edgexfoundry/app-rfid-llrp-inventory/internal/inventory"
 	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/common"
 )
 
 const (
-	maxBodyBytes   = 100 * 1024
-	readersRoute   = common.ApiBase + "/readers"
-	snapshotRoute  = common.ApiBase + "/inventory/snapshot"
-	cmdStartRoute  = common.ApiBase + "/command/reading/start"
-	cmdStopRoute   = common.ApiBase + "/command/reading/stop"
-	behaviorsRoute = common.ApiBase + "/behaviors/:name"
+	maxBodyBytes      = 100 * 1024
+	readersRoute      = common.ApiBase + "/readers"
+	readerStatusRoute = readersRoute + "/:name/status"
+	readerGPORoute    = readersRoute + "/:name/gpo"
+	snapshotRoute     = common.ApiBase + "/inventory/snapshot"
+	cmdStartRoute     = common.ApiBase + "/command/reading/start"
+	cmdStopRoute      = common.ApiBase + "/command/reading/stop"
+	behaviorsRoute    = common.ApiBase + "/behaviors/:name"
+	environmentsRoute = common.ApiBase + "/environments/:group"
+	tagsWriteRoute    = common.ApiBase + "/tags/write"
+	tagsLockRoute     = common.ApiBase + "/tags/lock"
+	tagsKillRoute     = common.ApiBase + "/tags/kill"
+	outboxRoute       = common.ApiBase + "/events/outbox"
+	ingestRoute       = common.ApiBase + "/reports/queue"
+	schedulesRoute    = common.ApiBase + "/schedules"
+	groupsRoute       = common.ApiBase + "/groups"
+	groupRoute        = groupsRoute + "/:name"
+	groupStartRoute   = groupRoute + "/start"
+	groupStopRoute    = groupRoute + "/stop"
 )
 
 func (app *InventoryApp) addRoutes() error {
@@ -35,6 +50,14 @@ func (app *InventoryApp) addRoutes() error {
 		readersRoute, http.MethodGet, app.getReaders); err != nil {
 		return err
 	}
+	if err := app.addRoute(
+		readerStatusRoute, http.MethodGet, app.getReaderStatus); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		readerGPORoute, http.MethodPost, app.postReaderGPO); err != nil {
+		return err
+	}
 	if err := app.addRoute(
 		snapshotRoute, http.MethodGet, app.getSnapshot); err != nil {
 		return err
@@ -55,6 +78,70 @@ func (app *InventoryApp) addRoutes() error {
 		behaviorsRoute, http.MethodPut, app.setBehavior); err != nil {
 		return err
 	}
+	if err := app.addRoute(
+		environmentsRoute, http.MethodGet, app.getEnvironment); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		environmentsRoute, http.MethodPut, app.setEnvironment); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		tagsWriteRoute, http.MethodPost, app.postTagWrite); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		tagsLockRoute, http.MethodPost, app.postTagLock); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		tagsKillRoute, http.MethodPost, app.postTagKill); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		outboxRoute, http.MethodGet, app.getOutbox); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		ingestRoute, http.MethodGet, app.getReportQueue); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		schedulesRoute, http.MethodGet, app.getSchedules); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		schedulesRoute, http.MethodPut, app.setSchedules); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		groupsRoute, http.MethodGet, app.getGroups); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		groupsRoute, http.MethodPost, app.createGroup); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		groupRoute, http.MethodGet, app.getGroup); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		groupRoute, http.MethodPut, app.updateGroup); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		groupRoute, http.MethodDelete, app.deleteGroup); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		groupStartRoute, http.MethodPost, app.startGroup); err != nil {
+		return err
+	}
+	if err := app.addRoute(
+		groupStopRoute, http.MethodPost, app.stopGroup); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -75,7 +162,8 @@ func (app *InventoryApp) index(ctx echo.Context) error {
 func (app *InventoryApp) getReaders(ctx echo.Context) error {
 	w := ctx.Response().Writer
 	w.Header().Set("Content-Type", "application/json")
-	if err := app.defaultGrp.WriteReaders(w); err != nil {
+	readers := struct{ Readers []string }{Readers: app.groups.readerNames()}
+	if err := json.NewEncoder(w).Encode(readers); err != nil {
 		msg := fmt.Sprintf("Failed to write readers list: %v", err)
 		app.lc.Error(msg)
 		return ctx.String(http.StatusInternalServerError, msg)
@@ -83,6 +171,55 @@ func (app *InventoryApp) getReaders(ctx echo.Context) error {
 	return nil
 }
 
+func (app *InventoryApp) getReaderStatus(ctx echo.Context) error {
+	name := ctx.Param("name")
+	health, ok := app.health.Health(name)
+	if !ok {
+		if !app.groups.hasReader(name) {
+			msg := fmt.Sprintf("Request for status of unknown reader. Name: %v", name)
+			app.lc.Error(msg)
+			return ctx.String(http.StatusNotFound, msg)
+		}
+		health = inventory.ReaderHealth{Device: name}
+	}
+	return ctx.JSON(http.StatusOK, health)
+}
+
+// postReaderGPO sets the named reader's GPO ports.
+func (app *InventoryApp) postReaderGPO(ctx echo.Context) error {
+	name := ctx.Param("name")
+	data, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxBodyBytes))
+	if err != nil {
+		msg := fmt.Sprintf("Failed to read GPO request: %v", err)
+		app.lc.Error(msg)
+		return ctx.String(http.StatusInternalServerError, msg)
+	}
+
+	var reqs []gpoRequest
+	if err := json.Unmarshal(data, &reqs); err != nil {
+		msg := fmt.Sprintf("Failed to unmarshal GPO request: %v. Body: %s", err, string(data))
+		app.lc.Error(msg)
+		return ctx.String(http.StatusBadRequest, msg)
+	}
+
+	if err := app.setGPO(name, reqs); err != nil {
+		msg := fmt.Sprintf("Failed to set GPO ports of reader %s: %v", name, err)
+		app.lc.Error(msg)
+
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, llrp.ErrInvalidGPO):
+			status = http.StatusBadRequest
+		case errors.Is(err, errUnknownReader):
+			status = http.StatusNotFound
+		}
+		return ctx.String(status, msg)
+	}
+
+	app.lc.Info("Set GPO ports.", "device", name, "ports", len(reqs))
+	return nil
+}
+
 func (app *InventoryApp) getSnapshot(ctx echo.Context) error {
 	w := ctx.Response().Writer
 	w.Header().Set("Content-Type", "application/json")
@@ -95,7 +232,7 @@ func (app *InventoryApp) getSnapshot(ctx echo.Context) error {
 }
 
 func (app *InventoryApp) startReading(ctx echo.Context) error {
-	if err := app.defaultGrp.StartAll(app.devService); err != nil {
+	if err := app.startGroups(""); err != nil {
 		msg := fmt.Sprintf("Failed to StartAll: %v", err)
 		app.lc.Error(msg)
 		return ctx.String(http.StatusInternalServerError, msg)
@@ -104,7 +241,7 @@ func (app *InventoryApp) startReading(ctx echo.Context) error {
 }
 
 func (app *InventoryApp) stopReading(ctx echo.Context) error {
-	if err := app.defaultGrp.StopAll(app.devService); err != nil {
+	if err := app.stopGroups(""); err != nil {
 		msg := fmt.Sprintf("Failed to StopAll: %v", err)
 		app.lc.Error(msg)
 		return ctx.String(http.StatusInternalServerError, msg)
@@ -112,75 +249,334 @@ func (app *InventoryApp) stopReading(ctx echo.Context) error {
 	return nil
 }
 
+// getOutbox reports the number and age of events waiting to be published.
+func (app *InventoryApp) getOutbox(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, app.outbox.stats())
+}
+
+// getReportQueue reports the status of the tag report queue,
+// including counts of reports dropped or coalesced because it was full.
+func (app *InventoryApp) getReportQueue(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, app.reports.snapshot())
+}
+
 func (app *InventoryApp) getBehavior(ctx echo.Context) error {
-	w := ctx.Response().Writer
 	bName := ctx.Param("name")
-	// Currently, only "default" is supported.
-	if bName != "default" {
+	grp, ok := app.groups.group(bName)
+	if !ok {
 		msg := fmt.Sprintf("Request to GET unknown behavior. Name: %v", bName)
 		app.lc.Error(msg)
+		return ctx.String(http.StatusNotFound, msg)
+	}
 
-		if _, err := w.Write([]byte("Invalid behavior name.")); err != nil {
-			app.lc.Error("Error writing failure response.", "error", err)
-		}
+	return ctx.JSON(http.StatusOK, grp.Behavior())
+}
+
+// setBehavior sets the Behavior of the reader group with the given name.
+func (app *InventoryApp) setBehavior(ctx echo.Context) error {
+	bName := ctx.Param("name")
+	if _, ok := app.groups.group(bName); !ok {
+		msg := fmt.Sprintf("Attempt to PUT unknown behavior. Name %v", bName)
+		app.lc.Error(msg)
 		return ctx.String(http.StatusNotFound, msg)
 	}
 
-	data, err := json.Marshal(app.defaultGrp.Behavior())
+	data, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxBodyBytes))
 	if err != nil {
-		msg := fmt.Sprintf("Failed to marshal behavior: %v", err)
+		msg := fmt.Sprintf("Failed to read behavior data: %v", err)
 		app.lc.Error(msg)
 		return ctx.String(http.StatusInternalServerError, msg)
 	}
 
-	if _, err := w.Write(data); err != nil {
-		msg := fmt.Sprintf("Failed to write behavior data: %v", err)
+	var b llrp.Behavior
+	if err := json.Unmarshal(data, &b); err != nil {
+		msg := fmt.Sprintf("Failed to unmarshal behavior data: %v. Body: %s", err, string(data))
 		app.lc.Error(msg)
-		return ctx.String(http.StatusInternalServerError, msg)
+		return ctx.String(http.StatusBadRequest, msg)
+	}
 
+	if err := app.setGroupBehavior(bName, b); err != nil {
+		msg := fmt.Sprintf("Failed to set net behavior: %v", err)
+		app.lc.Error(msg)
+		return ctx.String(http.StatusBadRequest, msg)
 	}
+
+	app.lc.Info("Updated behavior.", "name", bName)
 	return nil
 }
 
-func (app *InventoryApp) setBehavior(ctx echo.Context) error {
-	w := ctx.Response().Writer
-	req := ctx.Request()
-	bName := ctx.Param("name")
-	// Currently, only "default" is supported.
-	if bName != "default" {
-		msg := fmt.Sprintf("Attempt to PUT unknown behavior. Name %v", bName)
+// getEnvironment returns the Environment of the named reader group.
+func (app *InventoryApp) getEnvironment(ctx echo.Context) error {
+	name := ctx.Param("group")
+	grp, ok := app.groups.group(name)
+	if !ok {
+		msg := fmt.Sprintf("Request to GET environment of unknown reader group. Name: %v", name)
 		app.lc.Error(msg)
-		if _, err := w.Write([]byte("Invalid behavior name.")); err != nil {
-			app.lc.Error("Error writing failure response.", "error", err)
-		}
 		return ctx.String(http.StatusNotFound, msg)
 	}
 
-	data, err := io.ReadAll(io.LimitReader(req.Body, maxBodyBytes))
+	return ctx.JSON(http.StatusOK, grp.Environment())
+}
+
+// setEnvironment sets the Environment of the named reader group,
+// regenerating the ROSpecs of its readers.
+func (app *InventoryApp) setEnvironment(ctx echo.Context) error {
+	name := ctx.Param("group")
+	data, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxBodyBytes))
 	if err != nil {
-		msg := fmt.Sprintf("Failed to read behavior data: %v", err)
+		msg := fmt.Sprintf("Failed to read environment data: %v", err)
 		app.lc.Error(msg)
 		return ctx.String(http.StatusInternalServerError, msg)
 	}
 
-	var b llrp.Behavior
-	if err := json.Unmarshal(data, &b); err != nil {
-		msg := fmt.Sprintf("Failed to unmarshal behavior data: %v. Body: %s", err, string(data))
+	var e llrp.Environment
+	if err := json.Unmarshal(data, &e); err != nil {
+		msg := fmt.Sprintf("Failed to unmarshal environment data: %v. Body: %s", err, string(data))
+		app.lc.Error(msg)
+		return ctx.String(http.StatusBadRequest, msg)
+	}
+
+	if err := app.setGroupEnvironment(name, e); err != nil {
+		return app.groupError(ctx, "set environment of", err)
+	}
+
+	app.lc.Info("Updated environment.", "group", name)
+	grp, _ := app.groups.group(name)
+	return ctx.JSON(http.StatusOK, grp.Environment())
+}
+
+// getSchedules returns the reading schedules.
+func (app *InventoryApp) getSchedules(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, app.schedules.get())
+}
+
+// setSchedules replaces the reading schedules.
+// The new schedules are saved, and used in place of the configured schedules
+// until the configured schedules change.
+func (app *InventoryApp) setSchedules(ctx echo.Context) error {
+	data, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxBodyBytes))
+	if err != nil {
+		msg := fmt.Sprintf("Failed to read schedules: %v", err)
 		app.lc.Error(msg)
-		w.WriteHeader(http.StatusBadRequest)
 		return ctx.String(http.StatusInternalServerError, msg)
 	}
 
-	if err := app.defaultGrp.SetBehavior(app.devService, b); err != nil {
-		msg := fmt.Sprintf("Failed to set net behavior: %v", err)
+	var schedules []inventory.Schedule
+	if err := json.Unmarshal(data, &schedules); err != nil {
+		msg := fmt.Sprintf("Failed to unmarshal schedules: %v. Body: %s", err, string(data))
 		app.lc.Error(msg)
-		w.WriteHeader(http.StatusBadRequest)
-		if _, err := w.Write([]byte(err.Error())); err != nil {
-			app.lc.Error("Error writing failure response.", "error", err)
+		return ctx.String(http.StatusBadRequest, msg)
+	}
+
+	if err := app.replaceSchedules(schedules); err != nil {
+		msg := fmt.Sprintf("Failed to set schedules: %v", err)
+		app.lc.Error(msg)
+		if errors.Is(err, inventory.ErrInvalidSchedule) {
+			return ctx.String(http.StatusBadRequest, msg)
 		}
 		return ctx.String(http.StatusInternalServerError, msg)
 	}
 
-	app.lc.Info("Updated behavior.", "name", bName)
+	return ctx.JSON(http.StatusOK, app.schedules.get())
+}
+
+// getGroups returns the state of every reader group.
+func (app *InventoryApp) getGroups(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, app.groups.infos())
+}
+
+// getGroup returns the state of the named reader group.
+func (app *InventoryApp) getGroup(ctx echo.Context) error {
+	name := ctx.Param("name")
+	info, ok := app.groups.info(name)
+	if !ok {
+		msg := fmt.Sprintf("Request for unknown reader group. Name: %v", name)
+		app.lc.Error(msg)
+		return ctx.String(http.StatusNotFound, msg)
+	}
+	return ctx.JSON(http.StatusOK, info)
+}
+
+// createGroup creates a new reader group
+// and moves into it the readers that belong to it.
+func (app *InventoryApp) createGroup(ctx echo.Context) error {
+	spec, err := readGroupSpec(ctx)
+	if err != nil {
+		return app.groupError(ctx, "create", err)
+	}
+
+	err = app.groups.create(app.devService, spec)
+	app.saveGroups()
+	app.signalReadingState()
+	if err != nil {
+		return app.groupError(ctx, "create", err)
+	}
+
+	app.lc.Info("Created reader group.", "name", spec.Name)
+	info, _ := app.groups.info(spec.Name)
+	return ctx.JSON(http.StatusCreated, info)
+}
+
+// updateGroup changes the readers that belong to a reader group,
+// and its Behavior and Environment, if they're given.
+func (app *InventoryApp) updateGroup(ctx echo.Context) error {
+	spec, err := readGroupSpec(ctx)
+	if err != nil {
+		return app.groupError(ctx, "update", err)
+	}
+	spec.Name = ctx.Param("name")
+
+	err = app.groups.update(app.devService, spec)
+	app.saveGroups()
+	app.signalReadingState()
+	if err != nil {
+		return app.groupError(ctx, "update", err)
+	}
+
+	app.lc.Info("Updated reader group.", "name", spec.Name)
+	info, _ := app.groups.info(spec.Name)
+	return ctx.JSON(http.StatusOK, info)
+}
+
+// deleteGroup deletes a reader group,
+// moving its readers to the groups they now belong to.
+func (app *InventoryApp) deleteGroup(ctx echo.Context) error {
+	name := ctx.Param("name")
+	err := app.groups.delete(app.devService, name)
+	app.saveGroups()
+	app.signalReadingState()
+	if err != nil {
+		return app.groupError(ctx, "delete", err)
+	}
+
+	app.lc.Info("Deleted reader group.", "name", name)
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (app *InventoryApp) startGroup(ctx echo.Context) error {
+	if err := app.startGroups(ctx.Param("name")); err != nil {
+		return app.groupError(ctx, "start", err)
+	}
+	return nil
+}
+
+func (app *InventoryApp) stopGroup(ctx echo.Context) error {
+	if err := app.stopGroups(ctx.Param("name")); err != nil {
+		return app.groupError(ctx, "stop", err)
+	}
 	return nil
 }
+
+// readGroupSpec reads a groupSpec from the request body.
+func readGroupSpec(ctx echo.Context) (groupSpec, error) {
+	var spec groupSpec
+	data, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxBodyBytes))
+	if err != nil {
+		return spec, fmt.Errorf("failed to read request: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return spec, fmt.Errorf("%w: %v. Body: %s", errInvalidGroup, err, string(data))
+	}
+	return spec, nil
+}
+
+// groupError logs a failed reader group request
+// and responds with a status code appropriate for the error.
+func (app *InventoryApp) groupError(ctx echo.Context, op string, err error) error {
+	msg := fmt.Sprintf("Failed to %s reader group: %v", op, err)
+	app.lc.Error(msg)
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errInvalidGroup), errors.Is(err, llrp.ErrUnsatisfiable):
+		status = http.StatusBadRequest
+	case errors.Is(err, errUnknownGroup):
+		status = http.StatusNotFound
+	}
+	return ctx.String(status, msg)
+}
+
+func (app *InventoryApp) postTagWrite(ctx echo.Context) error {
+	data, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxBodyBytes))
+	if err != nil {
+		msg := fmt.Sprintf("Failed to read tag write request: %v", err)
+		app.lc.Error(msg)
+		return ctx.String(http.StatusInternalServerError, msg)
+	}
+
+	var req tagWriteRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		msg := fmt.Sprintf("Failed to unmarshal tag write request: %v. Body: %s", err, string(data))
+		app.lc.Error(msg)
+		return ctx.String(http.StatusBadRequest, msg)
+	}
+
+	resp, err := app.writeTags(req)
+	if err != nil {
+		return app.tagAccessError(ctx, "write", err)
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+func (app *InventoryApp) postTagLock(ctx echo.Context) error {
+	data, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxBodyBytes))
+	if err != nil {
+		msg := fmt.Sprintf("Failed to read tag lock request: %v", err)
+		app.lc.Error(msg)
+		return ctx.String(http.StatusInternalServerError, msg)
+	}
+
+	var req tagLockRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		msg := fmt.Sprintf("Failed to unmarshal tag lock request: %v. Body: %s", err, string(data))
+		app.lc.Error(msg)
+		return ctx.String(http.StatusBadRequest, msg)
+	}
+
+	resp, err := app.lockTags(req)
+	if err != nil {
+		return app.tagAccessError(ctx, "lock", err)
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+func (app *InventoryApp) postTagKill(ctx echo.Context) error {
+	data, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxBodyBytes))
+	if err != nil {
+		msg := fmt.Sprintf("Failed to read tag kill request: %v", err)
+		app.lc.Error(msg)
+		return ctx.String(http.StatusInternalServerError, msg)
+	}
+
+	var req tagKillRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		msg := fmt.Sprintf("Failed to unmarshal tag kill request: %v. Body: %s", err, string(data))
+		app.lc.Error(msg)
+		return ctx.String(http.StatusBadRequest, msg)
+	}
+
+	resp, err := app.killTags(req)
+	if err != nil {
+		return app.tagAccessError(ctx, "kill", err)
+	}
+	return ctx.JSON(http.StatusOK, resp)
+}
+
+// tagAccessError logs a failed tag access request
+// and responds with a status code appropriate for the error.
+func (app *InventoryApp) tagAccessError(ctx echo.Context, op string, err error) error {
+	msg := fmt.Sprintf("Failed to %s tags: %v", op, err)
+	app.lc.Error(msg)
+
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, errInvalidAccess):
+		status = http.StatusBadRequest
+	case errors.Is(err, errUnknownReader):
+		status = http.StatusNotFound
+	case errors.Is(err, errKillNotAllowed):
+		status = http.StatusForbidden
+	}
+	return ctx.String(status, msg)
+}
@@ -0,0 +1,261 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/inventory"
+)
+
+const scheduleCacheFile = "schedules.json"
+
+// scheduler holds the reading schedules and fires them at their scheduled times.
+type scheduler struct {
+	mu        sync.Mutex
+	schedules []inventory.Schedule
+	specs     []inventory.CronSpec
+	// configured are the configured schedules when they were last loaded.
+	configured []inventory.Schedule
+
+	// changed is signaled when the schedules are replaced.
+	changed chan struct{}
+}
+
+func newScheduler() *scheduler {
+	return &scheduler{changed: make(chan struct{}, 1)}
+}
+
+// set validates and replaces the schedules.
+func (s *scheduler) set(schedules []inventory.Schedule) error {
+	if err := inventory.ValidateSchedules(schedules); err != nil {
+		return err
+	}
+
+	specs := make([]inventory.CronSpec, len(schedules))
+	for i := range schedules {
+		specs[i], _ = inventory.ParseCron(schedules[i].Cron) // already validated
+	}
+
+	s.mu.Lock()
+	s.schedules = append([]inventory.Schedule(nil), schedules...)
+	s.specs = specs
+	s.mu.Unlock()
+
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// setConfigured records the configured schedules
+// and returns true if they differ from those last recorded.
+func (s *scheduler) setConfigured(configured []inventory.Schedule) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sameSchedules(s.configured, configured) {
+		return false
+	}
+	s.configured = configured
+	return true
+}
+
+// get returns a copy of the schedules.
+func (s *scheduler) get() []inventory.Schedule {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append(make([]inventory.Schedule, 0, len(s.schedules)), s.schedules...)
+}
+
+// next returns the next time after now at which any enabled schedule fires,
+// along with the schedules that fire then, in order.
+// If no schedule will fire, it returns the zero time.
+func (s *scheduler) next(now time.Time) (next time.Time, due []inventory.Schedule) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, sched := range s.schedules {
+		if sched.Disabled {
+			continue
+		}
+
+		t := s.specs[i].Next(now)
+		switch {
+		case t.IsZero():
+		case next.IsZero() || t.Before(next):
+			next, due = t, []inventory.Schedule{sched}
+		case t.Equal(next):
+			due = append(due, sched)
+		}
+	}
+	return next, due
+}
+
+// savedSchedules are the schedules persisted to the cache,
+// along with the configured schedules at the time they were saved.
+type savedSchedules struct {
+	Configured []inventory.Schedule `json:"configured"`
+	Schedules  []inventory.Schedule `json:"schedules"`
+}
+
+// loadSchedules sets the schedules last saved via the API,
+// unless the configured schedules have changed since then,
+// in which case it sets the configured schedules.
+func (app *InventoryApp) loadSchedules() {
+	configured := app.config.AppCustom.Schedules
+	app.schedules.setConfigured(configured)
+	schedules, source := configured, "configuration"
+
+	data, err := os.ReadFile(filepath.Join(cacheFolder, scheduleCacheFile))
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		app.lc.Warn("Failed to load saved schedules.", "error", err.Error())
+	default:
+		var saved savedSchedules
+		if err := json.Unmarshal(data, &saved); err != nil {
+			app.lc.Warn("Failed to unmarshal saved schedules.", "error", err.Error())
+			break
+		}
+		if sameSchedules(saved.Configured, configured) {
+			schedules, source = saved.Schedules, "cache"
+		}
+	}
+
+	if err := app.schedules.set(schedules); err != nil {
+		app.lc.Error("Invalid reading schedules; none will run.", "source", source, "error", err.Error())
+		return
+	}
+	app.lc.Info(fmt.Sprintf("Loaded %d reading schedules.", len(schedules)), "source", source)
+}
+
+// updateConfigSchedules replaces the schedules with the configured schedules
+// if they've changed since they were last loaded.
+func (app *InventoryApp) updateConfigSchedules(configured []inventory.Schedule) {
+	if err := inventory.ValidateSchedules(configured); err != nil {
+		app.lc.Error("Invalid reading schedules in configuration; keeping the current schedules.",
+			"error", err.Error())
+		return
+	}
+
+	if !app.schedules.setConfigured(configured) {
+		return
+	}
+	if err := app.replaceSchedules(configured); err != nil {
+		app.lc.Error("Failed to update reading schedules from configuration.", "error", err.Error())
+	}
+}
+
+// sameSchedules returns true if a and b hold the same schedules,
+// treating nil and empty lists as equal.
+func sameSchedules(a, b []inventory.Schedule) bool {
+	return len(a) == len(b) && (len(a) == 0 || reflect.DeepEqual(a, b))
+}
+
+// replaceSchedules validates, replaces, and persists the schedules.
+func (app *InventoryApp) replaceSchedules(schedules []inventory.Schedule) error {
+	if err := app.schedules.set(schedules); err != nil {
+		return err
+	}
+
+	app.schedules.mu.Lock()
+	saved := savedSchedules{Configured: app.schedules.configured, Schedules: schedules}
+	app.schedules.mu.Unlock()
+
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return fmt.Errorf("failed to marshal schedules: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(cacheFolder, scheduleCacheFile), data, filePerm); err != nil {
+		// the schedules are in effect, but won't survive a restart
+		app.lc.Warn("Failed to persist schedules.", "error", err.Error())
+	}
+
+	app.lc.Info(fmt.Sprintf("Updated reading schedules; there are now %d.", len(schedules)))
+	return nil
+}
+
+// runSchedules fires the schedules at their scheduled times until ctx is cancelled.
+func (app *InventoryApp) runSchedules(ctx context.Context) {
+	for {
+		next, due := app.schedules.next(time.Now())
+
+		var fire <-chan time.Time
+		var timer *time.Timer
+		if !next.IsZero() {
+			timer = time.NewTimer(time.Until(next))
+			fire = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return
+
+		case <-app.schedules.changed:
+			if timer != nil {
+				timer.Stop()
+			}
+
+		case <-fire:
+			events := make([]inventory.Event, 0, len(due))
+			for _, sched := range due {
+				// don't start or stop the readers once the service is stopping
+				if ctx.Err() != nil {
+					break
+				}
+				events = append(events, app.fireSchedule(sched, next))
+			}
+			if len(events) > 0 {
+				app.queueEvents(events)
+			}
+		}
+	}
+}
+
+// fireSchedule performs the schedule's action and returns an event describing the result.
+func (app *InventoryApp) fireSchedule(sched inventory.Schedule, at time.Time) inventory.Event {
+	app.lc.Info("Firing reading schedule.", "name", sched.Name, "action", string(sched.Action))
+
+	// Behaviors apply to the default group unless the schedule names a group.
+	behaviorGroup := sched.Group
+	if behaviorGroup == "" {
+		behaviorGroup = defaultGroup
+	}
+
+	var err error
+	switch sched.Action {
+	case inventory.ScheduleStart:
+		if sched.Behavior != nil {
+			err = app.setGroupBehavior(behaviorGroup, *sched.Behavior)
+		}
+		if err == nil {
+			err = app.startGroups(sched.Group)
+		}
+	case inventory.ScheduleStop:
+		err = app.stopGroups(sched.Group)
+	case inventory.ScheduleBehavior:
+		err = app.setGroupBehavior(behaviorGroup, *sched.Behavior)
+	}
+
+	fired := inventory.ScheduleFiredEvent{Name: sched.Name, Action: sched.Action, Timestamp: at.UnixMilli()}
+	if err != nil {
+		app.lc.Error("Reading schedule failed.", "name", sched.Name, "error", err.Error())
+		return inventory.ScheduleFailedEvent{ScheduleFiredEvent: fired, Error: err.Error()}
+	}
+	return fired
+}
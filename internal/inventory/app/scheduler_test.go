@@ -0,0 +1,140 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/inventory"
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestApp returns an app with only a default reader group,
+// using a temporary cache folder.
+func newTestApp(t *testing.T) *InventoryApp {
+	t.Helper()
+	t.Chdir(t.TempDir())
+	require.NoError(t, os.MkdirAll(cacheFolder, folderPerm))
+
+	app := NewInventoryApp()
+	app.lc = logger.NewMockClient()
+	app.groups = newReaderGroups(app.lc)
+	return app
+}
+
+func TestScheduler_next(t *testing.T) {
+	s := newScheduler()
+	require.NoError(t, s.set([]inventory.Schedule{
+		{Name: "hourly", Cron: "0 * * * *", Action: inventory.ScheduleStop},
+		{Name: "disabled", Cron: "* * * * *", Action: inventory.ScheduleStop, Disabled: true},
+		{Name: "morning", Cron: "0 6 * * *", Action: inventory.ScheduleStart},
+		{Name: "half-hourly", Cron: "30 * * * *", Action: inventory.ScheduleStop},
+	}))
+	require.Len(t, s.changed, 1, "replacing the schedules signals the change")
+
+	now := time.Date(2026, 3, 2, 5, 40, 0, 0, time.Local)
+	next, due := s.next(now)
+	assert.Equal(t, time.Date(2026, 3, 2, 6, 0, 0, 0, time.Local), next)
+	require.Len(t, due, 2, "schedules due at the same time fire together, in order")
+	assert.Equal(t, "hourly", due[0].Name)
+	assert.Equal(t, "morning", due[1].Name)
+
+	next, due = s.next(next)
+	assert.Equal(t, time.Date(2026, 3, 2, 6, 30, 0, 0, time.Local), next)
+	require.Len(t, due, 1)
+	assert.Equal(t, "half-hourly", due[0].Name)
+
+	require.NoError(t, s.set(nil))
+	next, due = s.next(now)
+	assert.True(t, next.IsZero())
+	assert.Empty(t, due)
+}
+
+func TestFireSchedule(t *testing.T) {
+	app := newTestApp(t)
+	at := time.Now()
+
+	event := app.fireSchedule(inventory.Schedule{Name: "stop", Action: inventory.ScheduleStop}, at)
+	assert.Equal(t, inventory.ScheduleFiredEvent{Name: "stop", Action: inventory.ScheduleStop,
+		Timestamp: at.UnixMilli()}, event)
+	assert.Len(t, app.readingState, 1, "stopping the readers signals the task loop")
+
+	behavior := llrp.Behavior{ScanType: llrp.ScanFast, Power: llrp.PowerTarget{Max: 3000}}
+	event = app.fireSchedule(inventory.Schedule{Name: "fast", Action: inventory.ScheduleBehavior,
+		Behavior: &behavior}, at)
+	assert.IsType(t, inventory.ScheduleFiredEvent{}, event)
+	grp, ok := app.groups.group(defaultGroup)
+	require.True(t, ok)
+	assert.Equal(t, behavior, grp.Behavior())
+
+	event = app.fireSchedule(inventory.Schedule{Name: "missing", Action: inventory.ScheduleStart,
+		Group: "Dock"}, at)
+	failed, ok := event.(inventory.ScheduleFailedEvent)
+	require.True(t, ok)
+	assert.Equal(t, "missing", failed.Name)
+	assert.NotEmpty(t, failed.Error)
+}
+
+func TestRunSchedules_cancel(t *testing.T) {
+	app := newTestApp(t)
+	require.NoError(t, app.schedules.set([]inventory.Schedule{
+		{Name: "hourly", Cron: "0 * * * *", Action: inventory.ScheduleStop},
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		app.runSchedules(ctx)
+		close(done)
+	}()
+
+	require.NoError(t, app.schedules.set(nil))
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("runSchedules should return once cancelled")
+	}
+}
+
+func TestSchedules_persistence(t *testing.T) {
+	app := newTestApp(t)
+	configured := []inventory.Schedule{{Name: "configured", Cron: "0 6 * * *", Action: inventory.ScheduleStart}}
+	app.config.AppCustom.Schedules = configured
+	app.loadSchedules()
+	assert.Equal(t, configured, app.schedules.get())
+
+	saved := []inventory.Schedule{{Name: "saved", Cron: "0 18 * * *", Action: inventory.ScheduleStop}}
+	require.NoError(t, app.replaceSchedules(saved))
+
+	// schedules saved via the API survive a restart
+	restarted := NewInventoryApp()
+	restarted.lc = app.lc
+	restarted.config.AppCustom.Schedules = configured
+	restarted.loadSchedules()
+	assert.Equal(t, saved, restarted.schedules.get())
+
+	// unless the configured schedules have changed since
+	reconfigured := []inventory.Schedule{{Name: "reconfigured", Cron: "0 7 * * *", Action: inventory.ScheduleStart}}
+	restarted = NewInventoryApp()
+	restarted.lc = app.lc
+	restarted.config.AppCustom.Schedules = reconfigured
+	restarted.loadSchedules()
+	assert.Equal(t, reconfigured, restarted.schedules.get())
+
+	// invalid schedules in the configuration are ignored
+	restarted.updateConfigSchedules([]inventory.Schedule{{Name: "invalid"}})
+	assert.Equal(t, reconfigured, restarted.schedules.get())
+	restarted.updateConfigSchedules(configured)
+	assert.Equal(t, configured, restarted.schedules.get())
+}
@@ -0,0 +1,365 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+)
+
+const (
+	// accessOpSpecIDBase is the first OpSpecID used for tag access requests.
+	// It's well above the OpSpecIDs used for a Behavior's MemoryReads,
+	// which start at 1 and are limited by the number of AccessSpecs a Reader supports.
+	accessOpSpecIDBase = 0x8000
+
+	defaultAccessTimeout = 2 * time.Second
+	maxAccessTimeout     = 30 * time.Second
+)
+
+const (
+	// Keys of the passwords in the tag password secret.
+	accessPasswordKey = "accessPassword"
+	killPasswordKey   = "killPassword"
+)
+
+var (
+	errUnknownReader  = errors.New("unknown reader")
+	errInvalidAccess  = errors.New("invalid tag access request")
+	errKillNotAllowed = errors.New("killing tags is disabled by AllowTagKill")
+)
+
+// tagAccess coordinates AccessSpecs run on behalf of API requests.
+//
+// Only one such AccessSpec runs at a time, across all Readers.
+// While it runs, results for its OpSpecID are forwarded from the taskLoop
+// to the request waiting for them.
+type tagAccess struct {
+	// mu serializes access operations.
+	mu     sync.Mutex
+	nextID uint16
+
+	// listenMu protects the fields identifying the current operation's listener.
+	listenMu sync.Mutex
+	device   string
+	opSpecID uint16
+	locks    []llrp.TagLock
+	results  chan<- llrp.AccessResult
+}
+
+// newOpSpecID returns the next OpSpecID to use for an access operation.
+// The caller must hold ta.mu.
+func (ta *tagAccess) newOpSpecID() uint16 {
+	id := accessOpSpecIDBase | ta.nextID
+	ta.nextID = (ta.nextID + 1) % accessOpSpecIDBase
+	return id
+}
+
+// listen sets the destination for results matching the device and OpSpecID.
+// Passing a nil channel stops forwarding results.
+// If the operation is a Lock, locks should be the changes it requested,
+// since Readers don't include them in the results.
+func (ta *tagAccess) listen(device string, opSpecID uint16, locks []llrp.TagLock, results chan<- llrp.AccessResult) {
+	ta.listenMu.Lock()
+	ta.device, ta.opSpecID, ta.locks, ta.results = device, opSpecID, locks, results
+	ta.listenMu.Unlock()
+}
+
+// dispatch forwards any access results in the tag data
+// to the current listener, if the results belong to it,
+// and returns the forwarded results.
+// It never blocks; if the listener is too slow, results are dropped,
+// but they are still returned.
+//
+// Kill results of earlier operations are returned too, even though no one is listening,
+// so tags killed after their request timed out are still removed from the inventory.
+func (ta *tagAccess) dispatch(device string, tags []llrp.TagReportData) (results []llrp.AccessResult) {
+	ta.listenMu.Lock()
+	defer ta.listenMu.Unlock()
+
+	for i := range tags {
+		for _, res := range tags[i].AccessResults() {
+			if ta.results == nil || ta.device != device || res.OpSpecID != ta.opSpecID {
+				if res.Operation == llrp.AccessOpKill && res.OpSpecID&accessOpSpecIDBase != 0 {
+					results = append(results, res) // a late result
+				}
+				continue
+			}
+
+			if res.Operation == llrp.AccessOpLock {
+				res.Locks = ta.locks
+			}
+
+			results = append(results, res)
+			select {
+			case ta.results <- res:
+			default:
+			}
+		}
+	}
+
+	return results
+}
+
+// runAccess adds and enables the AccessSpec on the named Reader,
+// collects its results until the timeout expires
+// or it has collected opCount results (if opCount is non-zero),
+// then deletes the AccessSpec and returns the results, at most one per EPC.
+// If the AccessSpec performs a Lock, locks should be the changes it requests.
+//
+// Readers only execute the first AccessSpec that matches a tag,
+// so the AccessSpecs performing the MemoryReads of the Reader's group's Behavior
+// are disabled while the AccessSpec runs.
+// Because AccessSpecs only execute during inventory,
+// tags will only be accessed if the Reader is actively reading.
+func (app *InventoryApp) runAccess(device string, spec *llrp.AccessSpec, locks []llrp.TagLock, opCount uint16, timeout time.Duration) ([]llrp.AccessResult, error) {
+	grp, ok := app.groups.groupOf(device)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", errUnknownReader, device)
+	}
+
+	if timeout <= 0 {
+		timeout = defaultAccessTimeout
+	} else if timeout > maxAccessTimeout {
+		timeout = maxAccessTimeout
+	}
+
+	opSpecID := uint16(spec.AccessSpecID) // #nosec G115 -- set from an OpSpecID by newOpSpecID
+	resultCh := make(chan llrp.AccessResult, 64)
+	app.access.listen(device, opSpecID, locks, resultCh)
+	defer app.access.listen("", 0, nil, nil)
+
+	readIDs, err := grp.AccessSpecIDs(device)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range readIDs {
+		if err := app.devService.DisableAccessSpec(device, id); err != nil {
+			return nil, err
+		}
+
+		defer func(id uint32) {
+			if err := app.devService.EnableAccessSpec(device, id); err != nil {
+				app.lc.Error("Failed to re-enable memory read AccessSpec.",
+					"device", device, "accessSpecID", id, "error", err.Error())
+			}
+		}(id)
+	}
+
+	if err := app.devService.AddAccessSpec(device, spec); err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		if err := app.devService.DeleteAccessSpec(device, spec.AccessSpecID); err != nil {
+			app.lc.Error("Failed to delete AccessSpec.",
+				"device", device, "accessSpecID", spec.AccessSpecID, "error", err.Error())
+		}
+	}()
+
+	if err := app.devService.EnableAccessSpec(device, spec.AccessSpecID); err != nil {
+		return nil, err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	results := []llrp.AccessResult{}
+	seen := map[string]int{}
+	for opCount == 0 || len(results) < int(opCount) {
+		select {
+		case <-timer.C:
+			return results, nil
+		case res := <-resultCh:
+			if idx, ok := seen[res.EPC]; ok {
+				results[idx] = res // keep the latest result for each tag
+				continue
+			}
+			seen[res.EPC] = len(results)
+			results = append(results, res)
+		}
+	}
+
+	return results, nil
+}
+
+// tagWriteRequest is the body of a request to write tag memory.
+type tagWriteRequest struct {
+	Device string `json:"device"`
+	llrp.TagTarget
+	llrp.TagWrite
+	// OperationCount is the number of tags to write before stopping;
+	// if it's 0, the service writes at most a single tag.
+	OperationCount uint16 `json:"operationCount,omitempty"`
+	// Timeout is the maximum time to wait for results;
+	// if it's 0, the service waits for defaultAccessTimeout.
+	Timeout llrp.Millisecs32 `json:"timeout,omitempty"`
+}
+
+// tagAccessResponse reports the per-tag results of a tag access request.
+type tagAccessResponse struct {
+	Device  string              `json:"device"`
+	Results []llrp.AccessResult `json:"results"`
+}
+
+// writeTags writes memory on the tags matching the request's target.
+func (app *InventoryApp) writeTags(req tagWriteRequest) (tagAccessResponse, error) {
+	opCount := req.OperationCount
+	if opCount == 0 {
+		opCount = 1
+	}
+
+	app.access.mu.Lock()
+	defer app.access.mu.Unlock()
+
+	spec, err := llrp.NewWriteAccessSpec(app.access.newOpSpecID(), req.TagTarget, req.TagWrite, opCount)
+	if err != nil {
+		return tagAccessResponse{}, fmt.Errorf("%w: %v", errInvalidAccess, err)
+	}
+
+	results, err := app.runAccess(req.Device, spec, nil, opCount, time.Duration(req.Timeout)*time.Millisecond)
+	if err != nil {
+		return tagAccessResponse{}, err
+	}
+
+	app.lc.Info("Wrote tag memory.", "device", req.Device, "results", len(results))
+	return tagAccessResponse{Device: req.Device, Results: results}, nil
+}
+
+// tagPasswords returns the named passwords from the configured tag password secret.
+// Passwords may be given in decimal or in hex with a "0x" prefix.
+func (app *InventoryApp) tagPasswords(keys ...string) (map[string]uint32, error) {
+	secretName := app.config.AppCustom.AppSettings.TagPasswordSecretName
+	secrets, err := app.service.SecretProvider().GetSecret(secretName, keys...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag passwords from secret %q: %w", secretName, err)
+	}
+
+	passwords := make(map[string]uint32, len(keys))
+	for _, key := range keys {
+		value, ok := secrets[key]
+		if !ok {
+			return nil, fmt.Errorf("secret %q is missing %q", secretName, key)
+		}
+
+		pwd, err := strconv.ParseUint(value, 0, 32)
+		if err != nil {
+			return nil, fmt.Errorf("secret %q has an invalid %q: %w", secretName, key, err)
+		}
+		passwords[key] = uint32(pwd)
+	}
+
+	return passwords, nil
+}
+
+// tagLockRequest is the body of a request to change the lock state of tag memory.
+type tagLockRequest struct {
+	Device string `json:"device"`
+	llrp.TagTarget
+	Locks []llrp.TagLock `json:"locks"`
+	// AllowPermanent must be true if any of the Locks are permanent.
+	AllowPermanent bool `json:"allowPermanent,omitempty"`
+	// OperationCount is the number of tags to lock before stopping;
+	// if it's 0, the service locks at most a single tag.
+	OperationCount uint16 `json:"operationCount,omitempty"`
+	// Timeout is the maximum time to wait for results;
+	// if it's 0, the service waits for defaultAccessTimeout.
+	Timeout llrp.Millisecs32 `json:"timeout,omitempty"`
+}
+
+// lockTags changes the lock state of the tags matching the request's target,
+// using the access password from the tag password secret.
+func (app *InventoryApp) lockTags(req tagLockRequest) (tagAccessResponse, error) {
+	if !req.AllowPermanent {
+		for _, l := range req.Locks {
+			if l.IsPermanent() {
+				return tagAccessResponse{}, fmt.Errorf("%w: %s lock on %s is permanent, but allowPermanent is false",
+					errInvalidAccess, l.Privilege, l.Data)
+			}
+		}
+	}
+
+	opCount := req.OperationCount
+	if opCount == 0 {
+		opCount = 1
+	}
+
+	passwords, err := app.tagPasswords(accessPasswordKey)
+	if err != nil {
+		return tagAccessResponse{}, err
+	}
+
+	app.access.mu.Lock()
+	defer app.access.mu.Unlock()
+
+	spec, err := llrp.NewLockAccessSpec(app.access.newOpSpecID(), req.TagTarget,
+		passwords[accessPasswordKey], req.Locks, opCount)
+	if err != nil {
+		return tagAccessResponse{}, fmt.Errorf("%w: %v", errInvalidAccess, err)
+	}
+
+	results, err := app.runAccess(req.Device, spec, req.Locks, opCount, time.Duration(req.Timeout)*time.Millisecond)
+	if err != nil {
+		return tagAccessResponse{}, err
+	}
+
+	app.lc.Info("Locked tag memory.", "device", req.Device, "results", len(results))
+	return tagAccessResponse{Device: req.Device, Results: results}, nil
+}
+
+// tagKillRequest is the body of a request to kill tags.
+type tagKillRequest struct {
+	Device string `json:"device"`
+	llrp.TagTarget
+	// OperationCount is the number of tags to kill before stopping;
+	// if it's 0, the service kills at most a single tag.
+	OperationCount uint16 `json:"operationCount,omitempty"`
+	// Timeout is the maximum time to wait for results;
+	// if it's 0, the service waits for defaultAccessTimeout.
+	Timeout llrp.Millisecs32 `json:"timeout,omitempty"`
+}
+
+// killTags permanently disables the tags matching the request's target,
+// using the kill password from the tag password secret.
+// It fails unless the service is configured to AllowTagKill.
+func (app *InventoryApp) killTags(req tagKillRequest) (tagAccessResponse, error) {
+	if !app.config.AppCustom.AppSettings.AllowTagKill {
+		return tagAccessResponse{}, errKillNotAllowed
+	}
+
+	opCount := req.OperationCount
+	if opCount == 0 {
+		opCount = 1
+	}
+
+	passwords, err := app.tagPasswords(killPasswordKey)
+	if err != nil {
+		return tagAccessResponse{}, err
+	}
+
+	app.access.mu.Lock()
+	defer app.access.mu.Unlock()
+
+	spec, err := llrp.NewKillAccessSpec(app.access.newOpSpecID(), req.TagTarget,
+		passwords[killPasswordKey], opCount)
+	if err != nil {
+		return tagAccessResponse{}, fmt.Errorf("%w: %v", errInvalidAccess, err)
+	}
+
+	results, err := app.runAccess(req.Device, spec, nil, opCount, time.Duration(req.Timeout)*time.Millisecond)
+	if err != nil {
+		return tagAccessResponse{}, err
+	}
+
+	app.lc.Info("Killed tags.", "device", req.Device, "results", len(results))
+	return tagAccessResponse{Device: req.Device, Results: results}, nil
+}
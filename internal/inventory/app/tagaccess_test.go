@@ -0,0 +1,101 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventoryapp
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func killed(epc byte, opSpecID uint16) llrp.TagReportData {
+	return llrp.TagReportData{
+		EPC96:                llrp.EPC96{EPC: []byte{epc}},
+		C1G2KillOpSpecResult: &llrp.C1G2KillOpSpecResult{OpSpecID: opSpecID},
+	}
+}
+
+func TestTagAccess_dispatch(t *testing.T) {
+	var ta tagAccess
+	late := ta.newOpSpecID()
+	current := ta.newOpSpecID()
+
+	// without a listener, only late kill results are returned
+	results := ta.dispatch("reader", []llrp.TagReportData{
+		killed(1, late),
+		killed(2, 1), // not an access operation's OpSpecID
+		{
+			EPC96:                 llrp.EPC96{EPC: []byte{3}},
+			C1G2WriteOpSpecResult: &llrp.C1G2WriteOpSpecResult{OpSpecID: late},
+		},
+	})
+	require.Len(t, results, 1)
+	assert.Equal(t, "01", results[0].EPC)
+	assert.True(t, results[0].Success)
+
+	resultCh := make(chan llrp.AccessResult, 4)
+	ta.listen("reader", current, nil, resultCh)
+	results = ta.dispatch("reader", []llrp.TagReportData{killed(4, current), killed(5, late)})
+	assert.Len(t, results, 2)
+	require.Len(t, resultCh, 1, "only the current operation's results are forwarded")
+	assert.Equal(t, "04", (<-resultCh).EPC)
+
+	// results from other Readers aren't forwarded
+	results = ta.dispatch("other", []llrp.TagReportData{killed(6, current)})
+	assert.Len(t, results, 1)
+	assert.Empty(t, resultCh)
+}
+
+func TestRunAccess_memoryReads(t *testing.T) {
+	impinjCaps := testReaderCaps
+	general := *impinjCaps.GeneralDeviceCapabilities
+	general.DeviceManufacturer = uint32(llrp.PENImpinj)
+	impinjCaps.GeneralDeviceCapabilities = &general
+
+	tests := []struct {
+		name     string
+		caps     llrp.GetReaderCapabilitiesResponse
+		disabled bool
+	}{
+		{name: "AccessSpecs", caps: testReaderCaps, disabled: true},
+		{name: "Impinj optimized read", caps: impinjCaps, disabled: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			td, ds := newTestDevicesWith(t, tt.caps)
+			app := newTestApp(t)
+			app.devService = ds
+			app.groups = newTestGroups(t, ds, map[string][]string{"r1": nil})
+
+			grp, ok := app.groups.group(defaultGroup)
+			require.True(t, ok)
+			b := grp.Behavior()
+			b.MemoryReads = []llrp.MemoryRead{{Name: "tid", MemoryBank: llrp.MemoryBankTID, WordCount: 2}}
+			b.ImpinjOptions = &llrp.ImpinjOptions{OptimizedRead: true}
+			require.NoError(t, grp.SetBehavior(ds, b))
+			td.sent("r1")
+
+			spec, err := llrp.NewWriteAccessSpec(app.access.newOpSpecID(), llrp.TagTarget{EPC: "0102"},
+				llrp.TagWrite{MemoryBank: llrp.MemoryBankUser, Data: "abcd"}, 1)
+			require.NoError(t, err)
+			results, err := app.runAccess("r1", spec, nil, 1, time.Millisecond)
+			require.NoError(t, err)
+			assert.Empty(t, results)
+
+			// the memory read AccessSpecs, if the Reader has them, are disabled and re-enabled
+			sent := td.sent("r1")
+			assert.Equal(t, tt.disabled, slices.Contains(sent, "disableAccessSpec"))
+			assert.Contains(t, sent, "AccessSpec")
+			assert.Contains(t, sent, "deleteAccessSpec")
+		})
+	}
+}
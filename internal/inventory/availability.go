@@ -0,0 +1,223 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"time"
+)
+
+// ProcessReaderAlerts updates the availability of readers and antennas
+// according to the connection and antenna alerts among the events.
+//
+// While a tag's location is unavailable, its departure clock is frozen,
+// so a reader going offline or an antenna being disconnected
+// doesn't cause its tags to depart.
+// When the location is available again, the clock resumes where it left off.
+//
+// If any tag's departure clock was frozen or resumed, it returns an updated snapshot.
+func (tp *TagProcessor) ProcessReaderAlerts(events []Event) (snapshot []StaticTag) {
+	now := time.Now().UnixMilli()
+	changed := 0
+	for _, e := range events {
+		alert, ok := e.(ReaderAlertEvent)
+		if !ok {
+			continue
+		}
+
+		switch alert.Alert {
+		case ReaderConnected:
+			changed += tp.setAvailable(alert.Device, now)
+		case ReaderDisconnected:
+			changed += tp.setUnavailable(alert.Device, now)
+		case AntennaConnected:
+			changed += tp.setAvailable(NewLocation(alert.Device, alert.AntennaID).String(), now)
+		case AntennaDisconnected:
+			changed += tp.setUnavailable(NewLocation(alert.Device, alert.AntennaID).String(), now)
+		}
+	}
+
+	if changed == 0 {
+		return nil
+	}
+	return tp.snapshot()
+}
+
+// SetReading tells the TagProcessor whether the readers are reading.
+//
+// Unless the StoppedDepartureMode is "Continue",
+// tags can't depart while the readers are stopped.
+// When they're started again, the tags' departure clocks resume where they left off,
+// or in "Rebase" mode, start over.
+// Starting the readers also ends any idle states set by SetReaderIdle,
+// and resumes the departure clocks of tags restored with frozen clocks,
+// since the time the service wasn't running doesn't count either.
+//
+// If any tag's departure clock was frozen or resumed, it returns an updated snapshot.
+func (tp *TagProcessor) SetReading(reading bool) (snapshot []StaticTag) {
+	now := time.Now().UnixMilli()
+
+	var changed int
+	switch {
+	case !reading && tp.config.stoppedMode != StoppedDepartureContinue && tp.stoppedSince == 0:
+		tp.stoppedSince = now
+		changed = tp.freeze(func(Location) bool { return true }, now)
+		tp.lc.Info("Reading stopped; suspending departures.", "tags", changed)
+
+	case reading && (tp.stoppedSince != 0 || len(tp.idle) != 0):
+		tp.stoppedSince = 0
+		tp.idle = make(map[string]int64)
+		changed = tp.resume(func(Location) bool { return true }, now, tp.config.stoppedMode == StoppedDepartureRebase)
+		tp.lc.Info("Reading started; resuming departures.", "tags", changed)
+	}
+
+	if reading && tp.restoredFrozen {
+		tp.restoredFrozen = false
+		restored := tp.resume(func(Location) bool { return true }, now, false)
+		tp.lc.Info("Reading started; resuming departures of restored tags.", "tags", restored)
+		changed += restored
+	}
+
+	if changed == 0 {
+		return nil
+	}
+	return tp.snapshot()
+}
+
+// SetReaderIdle tells the TagProcessor whether a reader is idle
+// because it's waiting for a trigger, such as a GPI, to start reading.
+// Idle readers are treated the same as stopped readers; see SetReading.
+//
+// If any tag's departure clock was frozen or resumed, it returns an updated snapshot.
+func (tp *TagProcessor) SetReaderIdle(device string, idle bool) (snapshot []StaticTag) {
+	now := time.Now().UnixMilli()
+	atDevice := func(loc Location) bool { return loc.DeviceName == device }
+
+	_, wasIdle := tp.idle[device]
+	var changed int
+	switch {
+	case idle && !wasIdle && tp.config.stoppedMode != StoppedDepartureContinue:
+		tp.idle[device] = now
+		changed = tp.freeze(atDevice, now)
+
+	case !idle && wasIdle:
+		delete(tp.idle, device)
+		changed = tp.resume(atDevice, now, tp.config.stoppedMode == StoppedDepartureRebase)
+	}
+
+	if changed == 0 {
+		return nil
+	}
+	return tp.snapshot()
+}
+
+// endStopped ends any stopped or idle states without resetting the departure clocks,
+// such as when the StoppedDepartureMode changes to "Continue".
+func (tp *TagProcessor) endStopped(nowMs int64) {
+	if tp.stoppedSince == 0 && len(tp.idle) == 0 {
+		return
+	}
+	tp.stoppedSince = 0
+	tp.idle = make(map[string]int64)
+	tp.resume(func(Location) bool { return true }, nowMs, false)
+}
+
+// isUnavailable returns true if the location's reader or antenna can't currently read tags,
+// the location's reader is stopped or idle, or a GPISignal gates its departures.
+func (tp *TagProcessor) isUnavailable(location Location) bool {
+	if location.IsEmpty() {
+		return false
+	}
+	if tp.stoppedSince != 0 {
+		return true
+	}
+	if _, ok := tp.idle[location.DeviceName]; ok {
+		return true
+	}
+	if tp.gatesDepartures(location.DeviceName) {
+		return true
+	}
+	if _, ok := tp.unavailable[location.DeviceName]; ok {
+		return true
+	}
+	_, ok := tp.unavailable[location.String()]
+	return ok
+}
+
+// affects returns true if the key, which is either a device name or location,
+// refers to the location or its reader.
+func affects(key string, location Location) bool {
+	return key == location.DeviceName || key == location.String()
+}
+
+// setUnavailable marks the reader or antenna identified by key as unavailable,
+// and freezes the departure clocks of the Present tags located there.
+func (tp *TagProcessor) setUnavailable(key string, nowMs int64) int {
+	if _, ok := tp.unavailable[key]; ok {
+		return 0
+	}
+	tp.unavailable[key] = nowMs
+
+	frozen := tp.freeze(func(loc Location) bool { return affects(key, loc) }, nowMs)
+	tp.lc.Info("Location unavailable; suspending departures.", "location", key, "tags", frozen)
+	return frozen
+}
+
+// setAvailable marks the reader or antenna identified by key as available,
+// and resumes the departure clocks of the tags located there,
+// unless their location remains unavailable for another reason.
+//
+// The time a tag went unread before its location became unavailable still counts,
+// but the time its location was unavailable doesn't.
+func (tp *TagProcessor) setAvailable(key string, nowMs int64) int {
+	since, ok := tp.unavailable[key]
+	if !ok {
+		return 0
+	}
+	delete(tp.unavailable, key)
+
+	resumed := tp.resume(func(loc Location) bool { return affects(key, loc) }, nowMs, false)
+	tp.lc.Info("Location available; resuming departures.", "location", key, "tags", resumed,
+		"unavailableMillis", nowMs-since)
+	return resumed
+}
+
+// freeze freezes the departure clocks of Present tags at matching locations,
+// and returns the number of tags whose clocks it froze.
+func (tp *TagProcessor) freeze(matches func(Location) bool, nowMs int64) int {
+	frozen := 0
+	for _, tag := range tp.inventory {
+		if tag.state == Present && tag.frozenAt == 0 && matches(tag.Location) {
+			tag.frozenAt = nowMs
+			frozen++
+		}
+	}
+	return frozen
+}
+
+// resume resumes the frozen departure clocks of tags at matching locations,
+// unless their location is still unavailable,
+// and returns the number of tags whose clocks it resumed.
+//
+// If rebase is true, the clocks start over,
+// as if the tags had been read when they resumed.
+// Otherwise, the time a tag went unread before its clock was frozen still counts.
+func (tp *TagProcessor) resume(matches func(Location) bool, nowMs int64, rebase bool) int {
+	resumed := 0
+	for _, tag := range tp.inventory {
+		if tag.frozenAt == 0 || !matches(tag.Location) || tp.isUnavailable(tag.Location) {
+			continue
+		}
+
+		unread := max(tag.frozenAt-max(tag.LastRead, tag.departureRef), 0)
+		if rebase {
+			unread = 0
+		}
+		tag.departureRef = nowMs - unread
+		tag.frozenAt = 0
+		resumed++
+	}
+	return resumed
+}
@@ -0,0 +1,220 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSuspendDepartures(t *testing.T) {
+	offline := nextSensor()
+	online := nextSensor()
+	cfg := NewServiceConfig()
+	cfg.AppCustom.AppSettings.DepartedThresholdSeconds = 5
+
+	ds := newTestDataset(cfg, 2)
+	atOffline, atOnline := ds.epcs[0], ds.epcs[1]
+	past := time.Now().Add(-10 * time.Second)
+
+	ds.readTag(t, atOffline, readParams{deviceName: offline, antenna: defaultAntenna, lastSeen: past})
+	ds.readTag(t, atOnline, readParams{deviceName: online, antenna: defaultAntenna, lastSeen: past})
+
+	ds.tp.ProcessReaderAlerts([]Event{ReaderAlertEvent{Device: offline, Alert: ReaderDisconnected}})
+	events, _ := ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, atOnline, events[0].(DepartedEvent).EPC)
+	assert.Equal(t, Present, ds.tp.inventory[atOffline].state)
+
+	// simulate a long outage: the tag was unread for 2 seconds before it started
+	tag := ds.tp.inventory[atOffline]
+	tag.LastRead = time.Now().Add(-time.Hour).UnixMilli()
+	tag.frozenAt = tag.LastRead + 2000
+
+	ds.tp.ProcessReaderAlerts([]Event{ReaderAlertEvent{Device: offline, Alert: ReaderConnected}})
+	events, _ = ds.tp.AggregateDeparted()
+	if err := ds.verifyNoEvents(events); err != nil {
+		t.Fatal(err)
+	}
+	assert.Zero(t, tag.frozenAt)
+	assert.InDelta(t, time.Now().Add(-2*time.Second).UnixMilli(), tag.departureRef, 1000)
+
+	// once the clock runs out, the tag departs as usual
+	tag.departureRef -= 5000
+	events, _ = ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, atOffline, events[0].(DepartedEvent).EPC)
+}
+
+func TestSuspendDepartures_Antenna(t *testing.T) {
+	sensor := nextSensor()
+	cfg := NewServiceConfig()
+	cfg.AppCustom.AppSettings.DepartedThresholdSeconds = 5
+
+	ds := newTestDataset(cfg, 2)
+	past := time.Now().Add(-10 * time.Second)
+	ds.readTag(t, ds.epcs[0], readParams{deviceName: sensor, antenna: defaultAntenna, lastSeen: past})
+
+	ds.tp.ProcessReaderAlerts([]Event{
+		ReaderAlertEvent{Device: sensor, Alert: AntennaDisconnected, AntennaID: defaultAntenna},
+		ReaderAlertEvent{Device: sensor, Alert: ReaderDisconnected},
+	})
+	events, _ := ds.tp.AggregateDeparted()
+	if err := ds.verifyNoEvents(events); err != nil {
+		t.Fatal(err)
+	}
+
+	// the reader reconnects, but the antenna remains disconnected
+	ds.tp.ProcessReaderAlerts([]Event{ReaderAlertEvent{Device: sensor, Alert: ReaderConnected}})
+	events, _ = ds.tp.AggregateDeparted()
+	if err := ds.verifyNoEvents(events); err != nil {
+		t.Fatal(err)
+	}
+	require.NotZero(t, ds.tp.inventory[ds.epcs[0]].frozenAt)
+
+	// reads from the antenna show it's available again
+	ds.readTag(t, ds.epcs[1], readParams{deviceName: sensor, antenna: defaultAntenna})
+	assert.Empty(t, ds.tp.unavailable)
+	assert.Zero(t, ds.tp.inventory[ds.epcs[0]].frozenAt)
+
+	events, _ = ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ds.epcs[0], events[0].(DepartedEvent).EPC)
+}
+
+func TestSetReading(t *testing.T) {
+	tests := []struct {
+		mode             string
+		departs          bool // whether the tag departs while reading is stopped
+		departsOnRestart bool // whether the tag departs as soon as reading restarts
+	}{
+		{mode: StoppedDeparturePause, departs: false, departsOnRestart: true},
+		{mode: StoppedDepartureRebase, departs: false, departsOnRestart: false},
+		{mode: StoppedDepartureContinue, departs: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.mode, func(t *testing.T) {
+			sensor := nextSensor()
+			cfg := NewServiceConfig()
+			cfg.AppCustom.AppSettings.DepartedThresholdSeconds = 5
+			cfg.AppCustom.AppSettings.StoppedDepartureMode = tc.mode
+
+			ds := newTestDataset(cfg, 1)
+			ds.readTag(t, ds.epcs[0], readParams{deviceName: sensor, antenna: defaultAntenna,
+				lastSeen: time.Now().Add(-10 * time.Second)})
+
+			snapshot := ds.tp.SetReading(false)
+			if tc.mode == StoppedDepartureContinue {
+				assert.Nil(t, snapshot)
+			} else {
+				require.Len(t, snapshot, 1)
+				assert.True(t, snapshot[0].DeparturePaused)
+			}
+
+			events, _ := ds.tp.AggregateDeparted()
+			if tc.departs {
+				if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			if err := ds.verifyNoEvents(events); err != nil {
+				t.Fatal(err)
+			}
+
+			snapshot = ds.tp.SetReading(true)
+			require.Len(t, snapshot, 1)
+			assert.False(t, snapshot[0].DeparturePaused)
+
+			events, _ = ds.tp.AggregateDeparted()
+			if tc.departsOnRestart {
+				require.NoError(t, ds.verifyEventPattern(events, 1, DepartedType))
+			} else {
+				require.NoError(t, ds.verifyNoEvents(events))
+			}
+		})
+	}
+}
+
+func TestSuspendDepartures_restored(t *testing.T) {
+	offline := nextSensor()
+	cfg := NewServiceConfig()
+	cfg.AppCustom.AppSettings.DepartedThresholdSeconds = 5
+
+	ds := newTestDataset(cfg, 1)
+	ds.readTag(t, ds.epcs[0], readParams{deviceName: offline, antenna: defaultAntenna})
+	ds.tp.ProcessReaderAlerts([]Event{ReaderAlertEvent{Device: offline, Alert: ReaderDisconnected}})
+
+	// simulate a restart after a long outage: the tag was unread for 2 seconds before it started
+	tag := ds.tp.inventory[ds.epcs[0]]
+	tag.LastRead = time.Now().Add(-time.Hour).UnixMilli()
+	tag.frozenAt = tag.LastRead + 2000
+	snapshot := ds.tp.snapshot()
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, tag.frozenAt, snapshot[0].FrozenAt)
+
+	ds.tp = NewTagProcessor(getTestingLogger(), cfg, snapshot)
+	restored := ds.tp.inventory[ds.epcs[0]]
+	assert.Equal(t, tag.frozenAt, restored.frozenAt)
+	events, _ := ds.tp.AggregateDeparted()
+	if err := ds.verifyNoEvents(events); err != nil {
+		t.Fatal(err)
+	}
+
+	// the clock resumes where it left off once reading starts
+	require.NotNil(t, ds.tp.SetReading(true))
+	assert.Zero(t, restored.frozenAt)
+	assert.InDelta(t, time.Now().Add(-2*time.Second).UnixMilli(), restored.departureRef, 1000)
+	events, _ = ds.tp.AggregateDeparted()
+	if err := ds.verifyNoEvents(events); err != nil {
+		t.Fatal(err)
+	}
+
+	// the resumed clock is restored too
+	ds.tp = NewTagProcessor(getTestingLogger(), cfg, ds.tp.snapshot())
+	assert.Equal(t, restored.departureRef, ds.tp.inventory[ds.epcs[0]].departureRef)
+	assert.Nil(t, ds.tp.SetReading(true))
+}
+
+func TestSetReaderIdle(t *testing.T) {
+	idle := nextSensor()
+	busy := nextSensor()
+	cfg := NewServiceConfig()
+	cfg.AppCustom.AppSettings.DepartedThresholdSeconds = 5
+
+	ds := newTestDataset(cfg, 2)
+	past := time.Now().Add(-10 * time.Second)
+	ds.readTag(t, ds.epcs[0], readParams{deviceName: idle, antenna: defaultAntenna, lastSeen: past})
+	ds.readTag(t, ds.epcs[1], readParams{deviceName: busy, antenna: defaultAntenna, lastSeen: past})
+
+	require.Len(t, ds.tp.SetReaderIdle(idle, true), 2)
+	assert.Nil(t, ds.tp.SetReaderIdle(idle, true), "repeated idle state shouldn't change anything")
+
+	events, _ := ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ds.epcs[1], events[0].(DepartedEvent).EPC)
+
+	// starting the readers ends the idle state
+	require.NotNil(t, ds.tp.SetReading(true))
+	assert.Empty(t, ds.tp.idle)
+	events, _ = ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, ds.epcs[0], events[0].(DepartedEvent).EPC)
+}
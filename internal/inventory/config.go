@@ -24,12 +24,82 @@ type ApplicationSettings struct {
 	AgeOutHours                  uint
 
 	AdjustLastReadOnByOrigin bool
+
+	// AliasChangeEvent is the type of event generated for Present tags
+	// whose location alias changes as a result of a configuration update.
+	// It must be one of "LocationRenamed", "Moved", or "None".
+	AliasChangeEvent string
+
+	// TagPasswordSecretName is the name of the secret holding the tag passwords
+	// used by lock ("accessPassword") and kill ("killPassword") operations.
+	TagPasswordSecretName string
+	// AllowTagKill must be true for the service to accept requests to kill tags.
+	AllowTagKill bool
+
+	// ExitTimeoutSeconds is how long a tag located at one of the ExitLocations
+	// must go unread before it departs. It must be >0 if there are ExitLocations.
+	ExitTimeoutSeconds uint
+
+	// LocationHistorySize is the number of most recent distinct locations tracked per tag,
+	// used to determine whether a departure is explained.
+	LocationHistorySize uint
+
+	// OutboxMaxEntries is the maximum number of event batches held in the outbox
+	// waiting to be published; once it's full, the oldest are dropped. 0 means unlimited.
+	OutboxMaxEntries uint
+	// PublishRetryMaxSeconds is the maximum delay between attempts to publish events
+	// while the message bus is unavailable.
+	PublishRetryMaxSeconds uint
+
+	// ReportQueueSize is the maximum number of tag reports waiting to be processed.
+	ReportQueueSize uint
+	// ReportQueuePolicy determines how new reports are handled when the report queue is full.
+	// It must be one of "Block", the default, or "DropOldest" or "Coalesce", which drop reports.
+	ReportQueuePolicy string
+
+	// StoppedDepartureMode determines how tags depart while reading is stopped,
+	// or while readers wait for a GPI or Periodic trigger.
+	// It must be one of "Pause", "Rebase", or "Continue".
+	StoppedDepartureMode string
 }
 
 // CustomConfig is the struct representation of the individual custom sections
 type CustomConfig struct {
 	AppSettings ApplicationSettings
 	Aliases     map[string]string
+	// ExitLocations lists locations, by default name or alias, which are exits,
+	// such as dock doors or store exits.
+	// Tags located at them depart after only ExitTimeoutSeconds without a read.
+	ExitLocations []string
+	// POSLocations lists locations, by default name or alias, such as points of sale,
+	// through which tags are expected to pass before they legitimately depart.
+	// If there are any ExitLocations or POSLocations,
+	// tags that depart without having passed through one of them
+	// generate an UnexplainedDeparture event.
+	POSLocations []string
+	// Schedules start and stop the readers, or change their Behavior, at scheduled times.
+	// Schedules set via the REST API take precedence until these change.
+	Schedules []Schedule
+	// OutputRules set readers' GPO ports in response to inventory events.
+	OutputRules []OutputRule
+	// GPISignals name readers' GPI ports, whose transitions are published as events,
+	// and which can gate departures and tag associations at the readers.
+	// The app enables the ports and their notifications at the readers.
+	GPISignals []GPISignal
+}
+
+// DepartedCheckSeconds returns the interval at which to check for departed tags.
+//
+// This is normally the DepartedCheckIntervalSeconds,
+// but if there are ExitLocations with a shorter ExitTimeoutSeconds,
+// it's reduced to that timeout (but never less than a second)
+// so tags depart through exits promptly.
+func (c CustomConfig) DepartedCheckSeconds() uint {
+	interval := c.AppSettings.DepartedCheckIntervalSeconds
+	if len(c.ExitLocations) != 0 && c.AppSettings.ExitTimeoutSeconds < interval {
+		interval = max(c.AppSettings.ExitTimeoutSeconds, 1)
+	}
+	return interval
 }
 
 // ServiceConfig is the struct representation that contains the custom config section
@@ -50,6 +120,31 @@ func (c *ServiceConfig) UpdateFromRaw(rawConfig interface{}) bool {
 	return true
 }
 
+// aliasChangeNone is the AliasChangeEvent value which disables events on alias changes.
+const aliasChangeNone = "None"
+
+// ReportQueuePolicy values.
+const (
+	// ReportQueueBlock blocks new reports until there's room in the queue.
+	ReportQueueBlock = "Block"
+	// ReportQueueDropOldest drops the oldest report of the device with the most queued reports.
+	ReportQueueDropOldest = "DropOldest"
+	// ReportQueueCoalesce merges a new report into one already queued from the same device.
+	ReportQueueCoalesce = "Coalesce"
+)
+
+// StoppedDepartureMode values.
+const (
+	// StoppedDeparturePause pauses departure timing while reading is stopped,
+	// so tags resume with the time they'd already gone unread.
+	StoppedDeparturePause = "Pause"
+	// StoppedDepartureRebase pauses departure timing while reading is stopped,
+	// and restarts it when reading resumes, as if every tag had just been read.
+	StoppedDepartureRebase = "Rebase"
+	// StoppedDepartureContinue departs tags even while reading is stopped.
+	StoppedDepartureContinue = "Continue"
+)
+
 var (
 	// ErrOutOfRange is returned if a config value is syntactically valid for its type,
 	// but otherwise outside of the acceptable range of valid values.
@@ -70,11 +165,36 @@ func NewServiceConfig() ServiceConfig {
 				DepartedCheckIntervalSeconds: 30,
 				AgeOutHours:                  336,
 				AdjustLastReadOnByOrigin:     true,
+				AliasChangeEvent:             string(LocationRenamedType),
+				TagPasswordSecretName:        "tag-passwords",
+				ExitTimeoutSeconds:           5,
+				LocationHistorySize:          10,
+				OutboxMaxEntries:             10000,
+				PublishRetryMaxSeconds:       60,
+				ReportQueueSize:              100,
+				ReportQueuePolicy:            ReportQueueBlock,
+				StoppedDepartureMode:         StoppedDeparturePause,
 			},
 		},
 	}
 }
 
+// Validate returns nil if the CustomConfig's settings are valid,
+// or the first validation error it encounters.
+// Its Schedules, OutputRules, and GPISignals are validated separately,
+// so an invalid one doesn't prevent the rest of the configuration from updating.
+func (c CustomConfig) Validate() error {
+	if err := c.AppSettings.Validate(); err != nil {
+		return err
+	}
+
+	if len(c.ExitLocations) != 0 && c.AppSettings.ExitTimeoutSeconds == 0 {
+		return fmt.Errorf("ExitTimeoutSeconds must be >0 when there are ExitLocations: %w", ErrOutOfRange)
+	}
+
+	return nil
+}
+
 // Validate returns nil if the ApplicationSettings are valid,
 // or the first validation error it encounters.
 func (as ApplicationSettings) Validate() error {
@@ -90,5 +210,26 @@ func (as ApplicationSettings) Validate() error {
 		return fmt.Errorf("AgeOutHours must be >0: %w", ErrOutOfRange)
 	}
 
+	switch as.AliasChangeEvent {
+	case "", aliasChangeNone, string(LocationRenamedType), string(MovedType):
+	default:
+		return fmt.Errorf("AliasChangeEvent must be one of %q, %q or %q, not %q: %w",
+			LocationRenamedType, MovedType, aliasChangeNone, as.AliasChangeEvent, ErrOutOfRange)
+	}
+
+	switch as.ReportQueuePolicy {
+	case "", ReportQueueBlock, ReportQueueDropOldest, ReportQueueCoalesce:
+	default:
+		return fmt.Errorf("ReportQueuePolicy must be one of %q, %q or %q, not %q: %w",
+			ReportQueueBlock, ReportQueueDropOldest, ReportQueueCoalesce, as.ReportQueuePolicy, ErrOutOfRange)
+	}
+
+	switch as.StoppedDepartureMode {
+	case "", StoppedDeparturePause, StoppedDepartureRebase, StoppedDepartureContinue:
+	default:
+		return fmt.Errorf("StoppedDepartureMode must be one of %q, %q or %q, not %q: %w",
+			StoppedDeparturePause, StoppedDepartureRebase, StoppedDepartureContinue, as.StoppedDepartureMode, ErrOutOfRange)
+	}
+
 	return nil
 }
@@ -17,6 +17,8 @@ func TestValidate(t *testing.T) {
 		DepartedThresholdSeconds     uint
 		DepartedCheckIntervalSeconds uint
 		AgeOutHours                  uint
+		AliasChangeEvent             string
+		ReportQueuePolicy            string
 		ExpectError                  bool
 	}{
 		{
@@ -44,6 +46,34 @@ func TestValidate(t *testing.T) {
 			DepartedCheckIntervalSeconds: 30,
 			AgeOutHours:                  0,
 			ExpectError:                  true,
+		}, {
+			Name:                         "Valid Alias Change Event",
+			DepartedThresholdSeconds:     600,
+			DepartedCheckIntervalSeconds: 30,
+			AgeOutHours:                  336,
+			AliasChangeEvent:             "Moved",
+			ExpectError:                  false,
+		}, {
+			Name:                         "Invalid Alias Change Event",
+			DepartedThresholdSeconds:     600,
+			DepartedCheckIntervalSeconds: 30,
+			AgeOutHours:                  336,
+			AliasChangeEvent:             "Arrived",
+			ExpectError:                  true,
+		}, {
+			Name:                         "Valid Report Queue Policy",
+			DepartedThresholdSeconds:     600,
+			DepartedCheckIntervalSeconds: 30,
+			AgeOutHours:                  336,
+			ReportQueuePolicy:            "DropOldest",
+			ExpectError:                  false,
+		}, {
+			Name:                         "Invalid Report Queue Policy",
+			DepartedThresholdSeconds:     600,
+			DepartedCheckIntervalSeconds: 30,
+			AgeOutHours:                  336,
+			ReportQueuePolicy:            "DropNewest",
+			ExpectError:                  true,
 		},
 	}
 
@@ -53,6 +83,8 @@ func TestValidate(t *testing.T) {
 				DepartedThresholdSeconds:     tc.DepartedThresholdSeconds,
 				DepartedCheckIntervalSeconds: tc.DepartedCheckIntervalSeconds,
 				AgeOutHours:                  tc.AgeOutHours,
+				AliasChangeEvent:             tc.AliasChangeEvent,
+				ReportQueuePolicy:            tc.ReportQueuePolicy,
 			}
 			err := appSettings.Validate()
 			if tc.ExpectError {
@@ -64,3 +96,36 @@ func TestValidate(t *testing.T) {
 	}
 
 }
+
+func TestCustomConfig_Validate(t *testing.T) {
+	cfg := NewServiceConfig().AppCustom
+	require.NoError(t, cfg.Validate())
+
+	cfg.AppSettings.ExitTimeoutSeconds = 0
+	require.NoError(t, cfg.Validate(), "the timeout doesn't matter without ExitLocations")
+
+	cfg.ExitLocations = []string{"DockDoor"}
+	require.ErrorIs(t, cfg.Validate(), ErrOutOfRange)
+
+	cfg.AppSettings.ExitTimeoutSeconds = 5
+	require.NoError(t, cfg.Validate())
+
+	cfg.AppSettings.AgeOutHours = 0
+	require.ErrorIs(t, cfg.Validate(), ErrOutOfRange)
+}
+
+func TestDepartedCheckSeconds(t *testing.T) {
+	cfg := NewServiceConfig().AppCustom
+	cfg.AppSettings.DepartedCheckIntervalSeconds = 30
+	cfg.AppSettings.ExitTimeoutSeconds = 5
+	require.Equal(t, uint(30), cfg.DepartedCheckSeconds())
+
+	cfg.ExitLocations = []string{"DockDoor"}
+	require.Equal(t, uint(5), cfg.DepartedCheckSeconds())
+
+	cfg.AppSettings.ExitTimeoutSeconds = 0
+	require.Equal(t, uint(1), cfg.DepartedCheckSeconds())
+
+	cfg.AppSettings.ExitTimeoutSeconds = 60
+	require.Equal(t, uint(30), cfg.DepartedCheckSeconds())
+}
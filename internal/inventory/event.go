@@ -18,8 +18,37 @@ const (
 	MovedType EventType = "Moved"
 	// DepartedType defines an inventory event when the tag is not seen for a long period of time.
 	DepartedType EventType = "Departed"
+	// LocationRenamedType defines an inventory event when the alias of a Present tag's Location
+	// changes due to a configuration update, even though the tag itself did not move.
+	LocationRenamedType EventType = "LocationRenamed"
+	// TIDConflictType defines an inventory event when a tag's EPC is observed with a TID
+	// different from the one(s) previously observed with it.
+	TIDConflictType EventType = "TIDConflict"
+	// TagKilledType defines an inventory event when a tag is permanently disabled
+	// by a kill operation. The tag is removed from the inventory.
+	TagKilledType EventType = "TagKilled"
+	// TagLockedType defines an inventory event when the lock state of a tag's memory
+	// is changed by a lock operation.
+	TagLockedType EventType = "TagLocked"
+	// UnexplainedDepartureType defines an inventory event when a tag departs
+	// without having passed through any exit or point of sale location.
+	UnexplainedDepartureType EventType = "UnexplainedDeparture"
+	// PositionUpdatedType defines an inventory event when a gateway in Location mode
+	// reports a tag at new XY coordinates.
+	PositionUpdatedType EventType = "PositionUpdated"
+	// DirectionOfTravelType defines an inventory event when a gateway in Direction mode
+	// reports a tag travelled from one of its sectors to another.
+	DirectionOfTravelType EventType = "DirectionOfTravel"
 )
 
+// eventTypes are the types of every inventory event,
+// including those defined alongside the events of readers, GPIs, and schedules.
+var eventTypes = []EventType{
+	ArrivedType, MovedType, DepartedType, LocationRenamedType, TIDConflictType,
+	TagKilledType, TagLockedType, UnexplainedDepartureType, PositionUpdatedType, DirectionOfTravelType,
+	GPISignalType, ReaderAlertType, ScheduleFiredType, ScheduleFailedType,
+}
+
 // BaseEvent is the foundation that all other inventory events are based on and includes the
 // values common between all of them.
 type BaseEvent struct {
@@ -32,6 +61,9 @@ type BaseEvent struct {
 	// Timestamp is the time at which this event occurred. It represents milliseconds
 	// since the Unix Epoch.
 	Timestamp int64 `json:"timestamp"`
+	// Memory holds the hex-encoded results of the configured tag memory reads,
+	// keyed by the name of the read.
+	Memory map[string]string `json:"memory,omitempty"`
 }
 
 // ArrivedEvent is an inventory event that is generated when a tag is seen for the first time, or
@@ -60,6 +92,87 @@ type DepartedEvent struct {
 	LastRead int64 `json:"last_read"`
 	// LastKnownLocation is the location that the tag was associated with before it Departed.
 	LastKnownLocation string `json:"last_known_location"`
+	// ViaExit is true if the tag departed early because its last known location is an exit.
+	ViaExit bool `json:"via_exit"`
+}
+
+// LocationRenamedEvent is an inventory event that is generated when the alias of a Present tag's
+// Location is changed by a configuration update.
+type LocationRenamedEvent struct {
+	BaseEvent
+	// OldLocation is the alias of the tag's location before the configuration update.
+	OldLocation string `json:"old_location"`
+	// NewLocation is the alias of the tag's location after the configuration update.
+	NewLocation string `json:"new_location"`
+}
+
+// TIDConflictEvent is an inventory event that is generated when an EPC is observed with a new TID
+// after having already been observed with a different one. This typically means two physical
+// tags carry the same EPC, e.g. because one is counterfeit or was mis-encoded.
+type TIDConflictEvent struct {
+	// BaseEvent's TID is the newly observed TID.
+	BaseEvent
+	// TIDs is the list of all distinct TIDs observed with this EPC.
+	TIDs []string `json:"tids"`
+	// Location is the location of the tag at the time the conflict was detected.
+	Location string `json:"location"`
+}
+
+// UnexplainedDepartureEvent is an inventory event that is generated in addition to a DepartedEvent
+// when the departing tag's recent locations don't include an exit or point of sale location,
+// e.g. because it vanished from a shelf.
+type UnexplainedDepartureEvent struct {
+	BaseEvent
+	// LastRead is the last time in which this tag was read (Unix Epoch milliseconds).
+	LastRead int64 `json:"last_read"`
+	// LastKnownLocation is the location that the tag was associated with before it Departed.
+	LastKnownLocation string `json:"last_known_location"`
+	// Path is the list of the tag's most recent locations, oldest first.
+	Path []string `json:"path"`
+}
+
+// TagKilledEvent is an inventory event that is generated when a tag is successfully killed.
+type TagKilledEvent struct {
+	BaseEvent
+	// Device is the name of the reader that killed the tag.
+	Device string `json:"device"`
+	// LastKnownLocation is the location of the tag before it was killed.
+	LastKnownLocation string `json:"last_known_location"`
+}
+
+// TagLockedEvent is an inventory event that is generated when a tag's memory lock state
+// is successfully changed.
+type TagLockedEvent struct {
+	BaseEvent
+	// Device is the name of the reader that locked the tag.
+	Device string `json:"device"`
+	// Location is the location of the tag at the time it was locked.
+	Location string `json:"location"`
+	// Locks maps each memory region changed by the operation to its new lock privilege.
+	Locks map[string]string `json:"locks"`
+}
+
+// PositionUpdatedEvent is an inventory event that is generated when a gateway in Location mode
+// first reports a tag's position, or reports it at different coordinates than before.
+type PositionUpdatedEvent struct {
+	BaseEvent
+	// Device is the name of the gateway that estimated the position.
+	Device string `json:"device"`
+	// X and Y are the tag's coordinates, in centimeters.
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+}
+
+// DirectionOfTravelEvent is an inventory event that is generated when a gateway in Direction mode
+// stops tracking a tag that it first and last read in different sectors.
+type DirectionOfTravelEvent struct {
+	BaseEvent
+	// Device is the name of the gateway that tracked the tag.
+	Device string `json:"device"`
+	// FromSector is the sector in which the gateway first read the tag.
+	FromSector uint8 `json:"from_sector"`
+	// ToSector is the sector in which the gateway last read the tag.
+	ToSector uint8 `json:"to_sector"`
 }
 
 // Event is an interface that is implemented to map Event structs to their corresponding
@@ -82,3 +195,38 @@ func (m MovedEvent) OfType() EventType {
 func (d DepartedEvent) OfType() EventType {
 	return DepartedType
 }
+
+// OfType for LocationRenamedEvent returns LocationRenamedType
+func (r LocationRenamedEvent) OfType() EventType {
+	return LocationRenamedType
+}
+
+// OfType for TIDConflictEvent returns TIDConflictType
+func (c TIDConflictEvent) OfType() EventType {
+	return TIDConflictType
+}
+
+// OfType for UnexplainedDepartureEvent returns UnexplainedDepartureType
+func (u UnexplainedDepartureEvent) OfType() EventType {
+	return UnexplainedDepartureType
+}
+
+// OfType for TagKilledEvent returns TagKilledType
+func (k TagKilledEvent) OfType() EventType {
+	return TagKilledType
+}
+
+// OfType for TagLockedEvent returns TagLockedType
+func (l TagLockedEvent) OfType() EventType {
+	return TagLockedType
+}
+
+// OfType for PositionUpdatedEvent returns PositionUpdatedType
+func (p PositionUpdatedEvent) OfType() EventType {
+	return PositionUpdatedType
+}
+
+// OfType for DirectionOfTravelEvent returns DirectionOfTravelType
+func (d DirectionOfTravelEvent) OfType() EventType {
+	return DirectionOfTravelType
+}
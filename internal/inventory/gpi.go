@@ -0,0 +1,206 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+)
+
+// GPISignal names a reader's GPI port, such as one wired to a dock door sensor,
+// so its transitions are published as GPISignal events.
+// It can also gate how tags are inventoried at the reader while the signal is inactive.
+//
+// A signal's state is unknown until the reader reports a change to it,
+// and signals in an unknown state don't gate anything.
+type GPISignal struct {
+	// Name identifies the signal in its events, e.g. "DockDoor3Open".
+	Name   string `json:"name"`
+	Device string `json:"device"`
+	Port   uint16 `json:"port"`
+	// ActiveLow signals are active while the port is low, rather than high.
+	ActiveLow bool `json:"activeLow,omitempty"`
+	// GateDepartures suspends departures of the tags located at the Device
+	// while the signal is inactive, e.g. so tags can't depart through a closed door.
+	GateDepartures bool `json:"gateDepartures,omitempty"`
+	// GateAssociations ignores the Device's reads of tags not already located at it
+	// while the signal is inactive, so stray reads, e.g. through a closed door,
+	// don't cause tags to arrive at or move to its locations.
+	GateAssociations bool `json:"gateAssociations,omitempty"`
+}
+
+// ErrInvalidGPISignal is returned when a GPISignal cannot be used.
+var ErrInvalidGPISignal = errors.New("invalid GPI signal")
+
+// ValidateGPISignals returns nil if every GPISignal is valid,
+// and no two share a name or a reader's port,
+// or the first validation error it encounters.
+func ValidateGPISignals(signals []GPISignal) error {
+	names := make(map[string]struct{}, len(signals))
+	ports := make(map[gpiPort]struct{}, len(signals))
+	for _, s := range signals {
+		if s.Name == "" {
+			return fmt.Errorf("GPI signal has no name: %w", ErrInvalidGPISignal)
+		}
+		if s.Device == "" || s.Port == 0 {
+			return fmt.Errorf("GPI signal %q must have a device and a port >0: %w", s.Name, ErrInvalidGPISignal)
+		}
+		if _, ok := names[s.Name]; ok {
+			return fmt.Errorf("GPI signal name %q is used more than once: %w", s.Name, ErrInvalidGPISignal)
+		}
+		p := gpiPort{device: s.Device, port: s.Port}
+		if _, ok := ports[p]; ok {
+			return fmt.Errorf("GPI signal %q uses port %d of %s, which has another signal: %w",
+				s.Name, s.Port, s.Device, ErrInvalidGPISignal)
+		}
+		names[s.Name], ports[p] = struct{}{}, struct{}{}
+	}
+	return nil
+}
+
+// GPISignalType defines an event generated when a GPISignal's port changes state.
+const GPISignalType EventType = "GPISignal"
+
+// GPISignalEvent is generated when a GPISignal's port changes state.
+type GPISignalEvent struct {
+	Name   string `json:"name"`
+	Device string `json:"device"`
+	Port   uint16 `json:"port"`
+	// State is the port's new state.
+	State bool `json:"state"`
+	// Active is whether the signal is now active, accounting for ActiveLow.
+	Active bool `json:"active"`
+	// Timestamp is the time at which the reader reported the change (Unix Epoch milliseconds).
+	Timestamp int64 `json:"timestamp"`
+}
+
+// OfType for GPISignalEvent returns GPISignalType
+func (g GPISignalEvent) OfType() EventType {
+	return GPISignalType
+}
+
+// gpiPort identifies a GPI port of a reader.
+type gpiPort struct {
+	device string
+	port   uint16
+}
+
+// newGPISignalSet returns the signals mapped by their ports,
+// or an error if they're invalid.
+func newGPISignalSet(signals []GPISignal) (map[gpiPort]GPISignal, error) {
+	if err := ValidateGPISignals(signals); err != nil {
+		return nil, err
+	}
+	set := make(map[gpiPort]GPISignal, len(signals))
+	for _, s := range signals {
+		set[gpiPort{device: s.Device, port: s.Port}] = s
+	}
+	return set, nil
+}
+
+// ProcessGPIEvent records the new state of a reader's GPI port.
+//
+// If the port has a GPISignal and its state changed, it returns a GPISignal event,
+// and if that changes whether the signal gates the reader's departures,
+// freezes or resumes the departure clocks of its tags and returns an updated snapshot.
+// Ports without a GPISignal are ignored.
+func (tp *TagProcessor) ProcessGPIEvent(device string, gpi llrp.GPIEvent, timestamp int64) (events []Event, snapshot []StaticTag) {
+	p := gpiPort{device: device, port: gpi.Port}
+	signal, ok := tp.config.gpiSignals[p]
+	if !ok {
+		return nil, nil
+	}
+	if state, known := tp.gpis[p]; known && state == gpi.Event {
+		return nil, nil
+	}
+
+	wasGated := tp.gatesDepartures(device)
+	tp.gpis[p] = gpi.Event
+	active := gpi.Event != signal.ActiveLow
+	tp.lc.Info("GPI signal changed.", "signal", signal.Name, "device", device, "port", gpi.Port, "active", active)
+	events = []Event{GPISignalEvent{
+		Name:      signal.Name,
+		Device:    device,
+		Port:      gpi.Port,
+		State:     gpi.Event,
+		Active:    active,
+		Timestamp: timestamp,
+	}}
+
+	if tp.regate(device, wasGated, time.Now().UnixMilli()) == 0 {
+		return events, nil
+	}
+	return events, tp.snapshot()
+}
+
+// isInactive returns true if the signal's port is in a known state
+// in which the signal isn't active.
+func (tp *TagProcessor) isInactive(s GPISignal) bool {
+	state, known := tp.gpis[gpiPort{device: s.Device, port: s.Port}]
+	return known && state == s.ActiveLow
+}
+
+// gatesDepartures returns true if an inactive GPISignal suspends the device's departures.
+func (tp *TagProcessor) gatesDepartures(device string) bool {
+	for _, s := range tp.config.gpiSignals {
+		if s.GateDepartures && s.Device == device && tp.isInactive(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// gatesAssociations returns true if an inactive GPISignal
+// prevents the device from associating tags with its locations.
+func (tp *TagProcessor) gatesAssociations(device string) bool {
+	for _, s := range tp.config.gpiSignals {
+		if s.GateAssociations && s.Device == device && tp.isInactive(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// gatedDevices returns the set of devices whose departures are suspended by GPISignals.
+func (tp *TagProcessor) gatedDevices() map[string]struct{} {
+	gated := make(map[string]struct{})
+	for _, s := range tp.config.gpiSignals {
+		if s.GateDepartures && tp.isInactive(s) {
+			gated[s.Device] = struct{}{}
+		}
+	}
+	return gated
+}
+
+// regate freezes or resumes the departure clocks of the tags at the device
+// if whether its departures are gated differs from wasGated,
+// and returns the number of tags whose clocks it froze or resumed.
+//
+// As when a location is unavailable, the time a tag went unread before its departures
+// were gated still counts, but the time they were gated doesn't.
+func (tp *TagProcessor) regate(device string, wasGated bool, nowMs int64) int {
+	atDevice := func(loc Location) bool { return loc.DeviceName == device }
+	switch gated := tp.gatesDepartures(device); {
+	case gated && !wasGated:
+		frozen := tp.freeze(atDevice, nowMs)
+		tp.lc.Info("GPI signal inactive; suspending departures.", "device", device, "tags", frozen)
+		return frozen
+	case !gated && wasGated:
+		resumed := tp.resume(atDevice, nowMs, false)
+		tp.lc.Info("GPI signal active; resuming departures.", "device", device, "tags", resumed)
+		return resumed
+	}
+	return 0
+}
+
+// isAssociated returns true if the tag with the EPC is Present at one of the device's locations.
+func (tp *TagProcessor) isAssociated(epc, device string) bool {
+	tag, ok := tp.inventory[epc]
+	return ok && tag.state == Present && tag.Location.DeviceName == device
+}
@@ -0,0 +1,177 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateGPISignals(t *testing.T) {
+	valid := GPISignal{Name: "DockDoor3Open", Device: "Reader-3", Port: 1}
+	assert.NoError(t, ValidateGPISignals(nil))
+	assert.NoError(t, ValidateGPISignals([]GPISignal{valid, {Name: "DockDoor4Open", Device: "Reader-3", Port: 2}}))
+
+	tests := map[string][]GPISignal{
+		"no name":        {{Device: "Reader-3", Port: 1}},
+		"no device":      {{Name: "DockDoor3Open", Port: 1}},
+		"no port":        {{Name: "DockDoor3Open", Device: "Reader-3"}},
+		"duplicate name": {valid, {Name: valid.Name, Device: "Reader-4", Port: 1}},
+		"duplicate port": {valid, {Name: "DockDoor4Open", Device: valid.Device, Port: valid.Port}},
+	}
+	for name, signals := range tests {
+		assert.ErrorIs(t, ValidateGPISignals(signals), ErrInvalidGPISignal, name)
+	}
+}
+
+func TestProcessGPIEvent(t *testing.T) {
+	sensor := nextSensor()
+	cfg := NewServiceConfig()
+	cfg.AppCustom.GPISignals = []GPISignal{
+		{Name: "DockDoor3Open", Device: sensor, Port: 1},
+		{Name: "DockDoor3Beam", Device: sensor, Port: 2, ActiveLow: true},
+	}
+	ds := newTestDataset(cfg, 0)
+
+	events, snapshot := ds.tp.ProcessGPIEvent(sensor, llrp.GPIEvent{Port: 1, Event: true}, 1000)
+	assert.Nil(t, snapshot)
+	assert.Equal(t, []Event{GPISignalEvent{
+		Name:      "DockDoor3Open",
+		Device:    sensor,
+		Port:      1,
+		State:     true,
+		Active:    true,
+		Timestamp: 1000,
+	}}, events)
+
+	events, _ = ds.tp.ProcessGPIEvent(sensor, llrp.GPIEvent{Port: 1, Event: true}, 2000)
+	assert.Empty(t, events, "a repeated state isn't a transition")
+
+	events, _ = ds.tp.ProcessGPIEvent(sensor, llrp.GPIEvent{Port: 2, Event: false}, 3000)
+	require.Len(t, events, 1)
+	assert.Equal(t, "DockDoor3Beam", events[0].(GPISignalEvent).Name)
+	assert.True(t, events[0].(GPISignalEvent).Active)
+
+	events, _ = ds.tp.ProcessGPIEvent(sensor, llrp.GPIEvent{Port: 3, Event: true}, 4000)
+	assert.Empty(t, events, "ports without a signal are ignored")
+	events, _ = ds.tp.ProcessGPIEvent(nextSensor(), llrp.GPIEvent{Port: 1, Event: true}, 4000)
+	assert.Empty(t, events, "ports without a signal are ignored")
+}
+
+func TestGPISignal_GateDepartures(t *testing.T) {
+	door := nextSensor()
+	other := nextSensor()
+	cfg := NewServiceConfig()
+	cfg.AppCustom.AppSettings.DepartedThresholdSeconds = 5
+	cfg.AppCustom.GPISignals = []GPISignal{{Name: "DockDoorOpen", Device: door, Port: 1, GateDepartures: true}}
+
+	ds := newTestDataset(cfg, 2)
+	atDoor, elsewhere := ds.epcs[0], ds.epcs[1]
+	past := time.Now().Add(-10 * time.Second)
+	ds.readTag(t, atDoor, readParams{deviceName: door, antenna: defaultAntenna, lastSeen: past})
+	ds.readTag(t, elsewhere, readParams{deviceName: other, antenna: defaultAntenna, lastSeen: past})
+
+	// the door closes
+	_, snapshot := ds.tp.ProcessGPIEvent(door, llrp.GPIEvent{Port: 1, Event: false}, 0)
+	require.Len(t, snapshot, 2)
+	assert.NotZero(t, ds.tp.inventory[atDoor].frozenAt)
+
+	events, _ := ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, elsewhere, events[0].(DepartedEvent).EPC)
+
+	// the door opens
+	_, snapshot = ds.tp.ProcessGPIEvent(door, llrp.GPIEvent{Port: 1, Event: true}, 0)
+	require.NotNil(t, snapshot)
+	assert.Zero(t, ds.tp.inventory[atDoor].frozenAt)
+
+	events, _ = ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, atDoor, events[0].(DepartedEvent).EPC)
+}
+
+func TestGPISignal_GateDepartures_ConfigChange(t *testing.T) {
+	door := nextSensor()
+	cfg := NewServiceConfig()
+	cfg.AppCustom.AppSettings.DepartedThresholdSeconds = 5
+	cfg.AppCustom.GPISignals = []GPISignal{{Name: "DockDoorOpen", Device: door, Port: 1, GateDepartures: true}}
+
+	ds := newTestDataset(cfg, 1)
+	ds.readTag(t, ds.epcs[0], readParams{deviceName: door, antenna: defaultAntenna,
+		lastSeen: time.Now().Add(-10 * time.Second)})
+	ds.tp.ProcessGPIEvent(door, llrp.GPIEvent{Port: 1, Event: false}, 0)
+	require.NotZero(t, ds.tp.inventory[ds.epcs[0]].frozenAt)
+
+	// invalid signals are ignored
+	invalid := cfg.AppCustom
+	invalid.GPISignals = []GPISignal{{Name: "DockDoorOpen"}}
+	_, snapshot := ds.tp.UpdateConfig(invalid)
+	assert.Nil(t, snapshot)
+	assert.True(t, ds.tp.gatesDepartures(door))
+
+	// the signal no longer gates departures
+	ungated := cfg.AppCustom
+	ungated.GPISignals = []GPISignal{{Name: "DockDoorOpen", Device: door, Port: 1}}
+	_, snapshot = ds.tp.UpdateConfig(ungated)
+	require.Len(t, snapshot, 1)
+	assert.False(t, snapshot[0].DeparturePaused)
+
+	events, _ := ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGPISignal_GateAssociations(t *testing.T) {
+	door := nextSensor()
+	other := nextSensor()
+	cfg := NewServiceConfig()
+	cfg.AppCustom.GPISignals = []GPISignal{{Name: "DockDoorOpen", Device: door, Port: 1, GateAssociations: true}}
+
+	ds := newTestDataset(cfg, 3)
+	atDoor, elsewhere, unknown := ds.epcs[0], ds.epcs[1], ds.epcs[2]
+	ds.readTag(t, atDoor, readParams{deviceName: door, antenna: defaultAntenna})
+	ds.readTag(t, elsewhere, readParams{deviceName: other, antenna: defaultAntenna})
+
+	// the door closes
+	ds.tp.ProcessGPIEvent(door, llrp.GPIEvent{Port: 1, Event: false}, 0)
+	lastRead := ds.tp.inventory[atDoor].LastRead
+
+	events := ds.readTag(t, unknown, readParams{deviceName: door, antenna: defaultAntenna})
+	if err := ds.verifyNoEvents(events); err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, ds.tp.inventory, unknown)
+
+	events = ds.readTag(t, elsewhere, readParams{deviceName: door, antenna: defaultAntenna, rssi: -40, count: 10})
+	if err := ds.verifyNoEvents(events); err != nil {
+		t.Fatal(err)
+	}
+	if err := ds.verifyTag(elsewhere, Present, ds.findAlias(other, defaultAntenna)); err != nil {
+		t.Fatal(err)
+	}
+
+	// tags already at the door are still read
+	ds.readTag(t, atDoor, readParams{deviceName: door, antenna: defaultAntenna,
+		lastSeen: time.Now().Add(time.Second)})
+	assert.Greater(t, ds.tp.inventory[atDoor].LastRead, lastRead)
+
+	// the door opens
+	ds.tp.ProcessGPIEvent(door, llrp.GPIEvent{Port: 1, Event: true}, 0)
+	events = ds.readTag(t, unknown, readParams{deviceName: door, antenna: defaultAntenna})
+	if err := ds.verifyEventPattern(events, 1, ArrivedType); err != nil {
+		t.Fatal(err)
+	}
+}
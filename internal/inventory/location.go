@@ -38,3 +38,25 @@ func (loc Location) IsEmpty() bool {
 func (loc Location) String() string {
 	return loc.DeviceName + "_" + strconv.Itoa(int(loc.AntennaID))
 }
+
+// Position is a tag's XY coordinates, as estimated by a gateway in Location mode.
+type Position struct {
+	// DeviceName is the name of the gateway that estimated the position.
+	DeviceName string `json:"device_name"`
+	// X and Y are the tag's coordinates, in centimeters.
+	X int32 `json:"x"`
+	Y int32 `json:"y"`
+	// Timestamp is when the gateway last read the tag (Unix Epoch milliseconds).
+	Timestamp int64 `json:"timestamp"`
+}
+
+// Direction is a tag's travel through the sectors of a gateway in Direction mode.
+type Direction struct {
+	// DeviceName is the name of the gateway that tracked the tag.
+	DeviceName string `json:"device_name"`
+	// FromSector and ToSector are the sectors in which the gateway first and last read the tag.
+	FromSector uint8 `json:"from_sector"`
+	ToSector   uint8 `json:"to_sector"`
+	// Timestamp is when the gateway last read the tag (Unix Epoch milliseconds).
+	Timestamp int64 `json:"timestamp"`
+}
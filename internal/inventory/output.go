@@ -0,0 +1,157 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+)
+
+// OutputRule sets a reader's GPO port when an inventory event matches it,
+// e.g. to light a stack light at a dock door when certain tags depart through it.
+type OutputRule struct {
+	Name string `json:"name"`
+	// Event is the type of event that triggers the rule.
+	Event EventType `json:"event"`
+	// Location, if set, limits the rule to events at the location, by default name or alias.
+	// An event's location is where the tag arrived, moved to, or was last known to be.
+	Location string `json:"location,omitempty"`
+	// EPCMin and EPCMax, if set, limit the rule to events for tags
+	// whose hex-encoded EPCs are within the inclusive range.
+	EPCMin string `json:"epcMin,omitempty"`
+	EPCMax string `json:"epcMax,omitempty"`
+
+	// Device is the name of the reader whose GPO port the rule sets.
+	Device string `json:"device"`
+	Port   uint16 `json:"port"`
+	// State is the value to which the rule sets the port.
+	State bool `json:"state"`
+	// Duration, if non-zero, is how long the port holds the State
+	// before it's set back to its opposite.
+	// If the rule fires again in the meantime, the port holds it for the full Duration again.
+	Duration llrp.Millisecs32 `json:"duration,omitempty"`
+	// Disabled rules never fire.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// ErrInvalidOutputRule is returned when an OutputRule cannot be used.
+var ErrInvalidOutputRule = errors.New("invalid output rule")
+
+// ValidateOutputRules returns nil if every OutputRule is valid and has a unique name,
+// or the first validation error it encounters.
+func ValidateOutputRules(rules []OutputRule) error {
+	names := make(map[string]struct{}, len(rules))
+	for _, r := range rules {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+		if _, ok := names[r.Name]; ok {
+			return fmt.Errorf("output rule name %q is used more than once: %w", r.Name, ErrInvalidOutputRule)
+		}
+		names[r.Name] = struct{}{}
+	}
+	return nil
+}
+
+// Validate returns nil if the OutputRule is valid.
+// It doesn't check whether its Device exists or has its Port.
+func (r OutputRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("output rule has no name: %w", ErrInvalidOutputRule)
+	}
+	if r.Event == "" {
+		return fmt.Errorf("output rule %q has no event: %w", r.Name, ErrInvalidOutputRule)
+	}
+	if !slices.Contains(eventTypes, r.Event) {
+		return fmt.Errorf("output rule %q has unknown event %q: %w", r.Name, r.Event, ErrInvalidOutputRule)
+	}
+	if r.Device == "" || r.Port == 0 {
+		return fmt.Errorf("output rule %q must have a device and a port >0: %w", r.Name, ErrInvalidOutputRule)
+	}
+
+	for _, epc := range []string{r.EPCMin, r.EPCMax} {
+		if strings.Trim(strings.ToLower(epc), hexDigits) != "" {
+			return fmt.Errorf("output rule %q EPC range bound %q is not hex: %w", r.Name, epc, ErrInvalidOutputRule)
+		}
+	}
+	if r.EPCMin != "" && r.EPCMax != "" && compareHex(r.EPCMin, r.EPCMax) > 0 {
+		return fmt.Errorf("output rule %q EPC range is empty (%s > %s): %w",
+			r.Name, r.EPCMin, r.EPCMax, ErrInvalidOutputRule)
+	}
+
+	return nil
+}
+
+// Matches returns true if the event triggers the rule.
+// The aliases are used to match the rule's Location to the event's,
+// since events refer to locations by their aliases.
+func (r OutputRule) Matches(e Event, aliases map[string]string) bool {
+	if r.Disabled || e.OfType() != r.Event {
+		return false
+	}
+
+	epc, location := eventSubject(e)
+	if r.Location != "" && r.Location != location && aliasOf(aliases, r.Location) != location {
+		return false
+	}
+
+	if r.EPCMin == "" && r.EPCMax == "" {
+		return true
+	}
+	if epc == "" {
+		return false // it's not a tag event
+	}
+	return (r.EPCMin == "" || compareHex(r.EPCMin, epc) <= 0) &&
+		(r.EPCMax == "" || compareHex(epc, r.EPCMax) <= 0)
+}
+
+// eventSubject returns the EPC of the tag an event is about, if any,
+// and the location at which it happened, if it has one.
+func eventSubject(e Event) (epc, location string) {
+	switch e := e.(type) {
+	case ArrivedEvent:
+		return e.EPC, e.Location
+	case MovedEvent:
+		return e.EPC, e.NewLocation
+	case DepartedEvent:
+		return e.EPC, e.LastKnownLocation
+	case LocationRenamedEvent:
+		return e.EPC, e.NewLocation
+	case TIDConflictEvent:
+		return e.EPC, e.Location
+	case UnexplainedDepartureEvent:
+		return e.EPC, e.LastKnownLocation
+	case TagKilledEvent:
+		return e.EPC, e.LastKnownLocation
+	case TagLockedEvent:
+		return e.EPC, e.Location
+	case PositionUpdatedEvent:
+		return e.EPC, ""
+	case DirectionOfTravelEvent:
+		return e.EPC, ""
+	}
+	return "", ""
+}
+
+const hexDigits = "0123456789abcdef"
+
+// compareHex compares two hex-encoded numbers, ignoring case and leading zeros,
+// and returns -1, 0, or 1 if a is less than, equal to, or greater than b.
+func compareHex(a, b string) int {
+	a = strings.TrimLeft(strings.ToLower(a), "0")
+	b = strings.TrimLeft(strings.ToLower(b), "0")
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
@@ -0,0 +1,85 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateOutputRules(t *testing.T) {
+	valid := OutputRule{Name: "a", Event: DepartedType, Device: "reader", Port: 1}
+	with := func(f func(r *OutputRule)) []OutputRule {
+		r := valid
+		f(&r)
+		return []OutputRule{r}
+	}
+
+	tests := []struct {
+		name    string
+		rules   []OutputRule
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"valid", []OutputRule{valid}, false},
+		{"epc range", with(func(r *OutputRule) { r.EPCMin, r.EPCMax = "3000", "30FF" }), false},
+		{"no name", with(func(r *OutputRule) { r.Name = "" }), true},
+		{"no event", with(func(r *OutputRule) { r.Event = "" }), true},
+		{"unknown event", with(func(r *OutputRule) { r.Event = "Departure" }), true},
+		{"gpi event", with(func(r *OutputRule) { r.Event = GPISignalType }), false},
+		{"no device", with(func(r *OutputRule) { r.Device = "" }), true},
+		{"no port", with(func(r *OutputRule) { r.Port = 0 }), true},
+		{"not hex", with(func(r *OutputRule) { r.EPCMin = "30-00" }), true},
+		{"shorter bound", with(func(r *OutputRule) { r.EPCMin, r.EPCMax = "31", "30ff" }), false},
+		{"reversed range", with(func(r *OutputRule) { r.EPCMin, r.EPCMax = "3100", "30ff" }), true},
+		{"duplicate names", []OutputRule{valid, valid}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateOutputRules(tc.rules)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidOutputRule)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestOutputRule_Matches(t *testing.T) {
+	rule := OutputRule{
+		Name:     "dock",
+		Event:    DepartedType,
+		Location: "reader_1",
+		EPCMin:   "30000000",
+		EPCMax:   "300000ff",
+		Device:   "reader",
+		Port:     1,
+	}
+	aliases := map[string]string{"reader_1": "DockDoor"}
+	departed := func(epc, location string) Event {
+		return DepartedEvent{BaseEvent: BaseEvent{EPC: epc}, LastKnownLocation: location}
+	}
+
+	assert.True(t, rule.Matches(departed("30000010", "DockDoor"), aliases))
+	assert.True(t, rule.Matches(departed("300000FF", "DockDoor"), aliases))
+	assert.True(t, rule.Matches(departed("30000000", "reader_1"), nil))
+	assert.False(t, rule.Matches(departed("30000100", "DockDoor"), aliases))
+	assert.False(t, rule.Matches(departed("2fffffff", "DockDoor"), aliases))
+	assert.False(t, rule.Matches(departed("30000010", "reader_2"), aliases))
+	assert.False(t, rule.Matches(ArrivedEvent{BaseEvent: BaseEvent{EPC: "30000010"}, Location: "DockDoor"}, aliases))
+
+	rule.Disabled = true
+	assert.False(t, rule.Matches(departed("30000010", "DockDoor"), aliases))
+
+	// Rules without a location or EPC range match any event of their type.
+	anywhere := OutputRule{Name: "any", Event: ScheduleFiredType, Device: "reader", Port: 2}
+	assert.True(t, anywhere.Matches(ScheduleFiredEvent{Name: "open"}, nil))
+	anywhere.EPCMax = "ff"
+	assert.False(t, anywhere.Matches(ScheduleFiredEvent{Name: "open"}, nil))
+}
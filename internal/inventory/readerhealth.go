@@ -0,0 +1,243 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"sync"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+)
+
+// ReaderAlertType defines an event when the health of a reader changes,
+// as reported by its ReaderEventNotifications.
+const ReaderAlertType EventType = "ReaderAlert"
+
+// ReaderAlert describes the health transition that generated a ReaderAlertEvent.
+type ReaderAlert string
+
+const (
+	ReaderConnected      ReaderAlert = "Connected"
+	ReaderDisconnected   ReaderAlert = "Disconnected"
+	AntennaConnected     ReaderAlert = "AntennaConnected"
+	AntennaDisconnected  ReaderAlert = "AntennaDisconnected"
+	ReaderException      ReaderAlert = "ReaderException"
+	ReportBufferWarning  ReaderAlert = "ReportBufferWarning"
+	ReportBufferOverflow ReaderAlert = "ReportBufferOverflow"
+)
+
+// ReaderAlertEvent is an event generated when a reader's health changes.
+type ReaderAlertEvent struct {
+	// Device is the name of the reader.
+	Device string      `json:"device"`
+	Alert  ReaderAlert `json:"alert"`
+	// Timestamp is the time at which the reader reported the change (Unix Epoch milliseconds).
+	Timestamp int64 `json:"timestamp"`
+	// AntennaID is the antenna for antenna alerts, or the one involved in an exception, if any.
+	AntennaID uint16 `json:"antenna_id,omitempty"`
+	// Message is the reader's description of an exception.
+	Message string `json:"message,omitempty"`
+	// BufferLevel is the report buffer's fill percentage for buffer warnings.
+	BufferLevel uint8 `json:"buffer_level,omitempty"`
+}
+
+// OfType for ReaderAlertEvent returns ReaderAlertType
+func (r ReaderAlertEvent) OfType() EventType {
+	return ReaderAlertType
+}
+
+// ReaderHealth is the most recently known state of a reader.
+type ReaderHealth struct {
+	Device    string `json:"device"`
+	Connected bool   `json:"connected"`
+	// LastConnected and LastDisconnected are Unix Epoch milliseconds, or 0 if never observed.
+	LastConnected    int64 `json:"last_connected,omitempty"`
+	LastDisconnected int64 `json:"last_disconnected,omitempty"`
+	// Antennas holds whether each antenna is connected,
+	// for those antennas the reader has reported on.
+	Antennas map[uint16]bool `json:"antennas,omitempty"`
+	// LastException is the most recent ReaderException alert, if any.
+	LastException *ReaderAlertEvent `json:"last_exception,omitempty"`
+	// BufferWarnings and BufferOverflows count the report buffer alerts.
+	BufferWarnings  uint `json:"buffer_warnings"`
+	BufferOverflows uint `json:"buffer_overflows"`
+	// LastBufferAlert is the most recent report buffer alert, if any.
+	LastBufferAlert *ReaderAlertEvent `json:"last_buffer_alert,omitempty"`
+	// ROSpecRunning is true if the reader most recently reported that an ROSpec started.
+	ROSpecRunning bool `json:"rospec_running"`
+	// GPIs holds the most recently reported state of each GPI port.
+	GPIs map[uint16]bool `json:"gpis,omitempty"`
+	// LastReport is the time the service last received a tag report from the reader
+	// (Unix Epoch milliseconds), or 0 if it hasn't.
+	LastReport int64 `json:"last_report,omitempty"`
+}
+
+// HealthMonitor tracks the ReaderHealth of each reader. It is safe for concurrent use.
+type HealthMonitor struct {
+	mu      sync.Mutex
+	readers map[string]*ReaderHealth
+}
+
+// NewHealthMonitor returns a HealthMonitor that isn't tracking any readers.
+func NewHealthMonitor() *HealthMonitor {
+	return &HealthMonitor{readers: make(map[string]*ReaderHealth)}
+}
+
+// get returns the device's health record, creating it if necessary.
+// It must be called while holding the lock.
+func (hm *HealthMonitor) get(device string) *ReaderHealth {
+	h, ok := hm.readers[device]
+	if !ok {
+		h = &ReaderHealth{Device: device}
+		hm.readers[device] = h
+	}
+	return h
+}
+
+// SetConnected marks the device as connected without generating an alert,
+// such as when it's discovered when the service starts.
+func (hm *HealthMonitor) SetConnected(device string, now time.Time) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	h := hm.get(device)
+	if !h.Connected {
+		h.Connected = true
+		h.LastConnected = now.UnixMilli()
+	}
+}
+
+// ReportReceived records that a tag report was received from the device.
+func (hm *HealthMonitor) ReportReceived(device string, now time.Time) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	hm.get(device).LastReport = now.UnixMilli()
+}
+
+// Health returns a copy of the device's health,
+// or false if the device isn't known.
+func (hm *HealthMonitor) Health(device string) (ReaderHealth, bool) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	h, ok := hm.readers[device]
+	if !ok {
+		return ReaderHealth{}, false
+	}
+
+	c := *h
+	c.Antennas = copyBoolMap(h.Antennas)
+	c.GPIs = copyBoolMap(h.GPIs)
+	return c, true
+}
+
+func copyBoolMap(m map[uint16]bool) map[uint16]bool {
+	if len(m) == 0 {
+		return nil
+	}
+	c := make(map[uint16]bool, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// NotificationTimestamp returns the time at which the reader sent the notification
+// (Unix Epoch milliseconds), or now if the notification doesn't say.
+func NotificationTimestamp(n *llrp.ReaderEventNotification, now time.Time) int64 {
+	if n.ReaderEventNotificationData.UTCTimestamp != 0 {
+		return int64(n.ReaderEventNotificationData.UTCTimestamp / 1000) // #nosec G115
+	}
+	return now.UnixMilli()
+}
+
+// HandleNotification updates the device's health according to the notification,
+// and returns a ReaderAlertEvent for each change in its health.
+//
+// Changes in connection and antenna state generate alerts,
+// as does every exception and report buffer warning or overflow.
+// ROSpec and GPI changes are part of normal operation,
+// so they're tracked without generating alerts.
+func (hm *HealthMonitor) HandleNotification(device string, n *llrp.ReaderEventNotification, now time.Time) (events []Event) {
+	hm.mu.Lock()
+	defer hm.mu.Unlock()
+
+	h := hm.get(device)
+	data := &n.ReaderEventNotificationData
+	ts := NotificationTimestamp(n, now)
+
+	if data.ConnectionAttemptEvent != nil && *data.ConnectionAttemptEvent == llrp.ConnectionAttemptEvent(llrp.ConnSuccess) {
+		h.LastConnected = ts
+		if !h.Connected {
+			h.Connected = true
+			events = append(events, ReaderAlertEvent{Device: device, Alert: ReaderConnected, Timestamp: ts})
+		}
+	}
+
+	if data.AntennaEvent != nil {
+		id := uint16(data.AntennaEvent.AntennaID)
+		connected := data.AntennaEvent.Event == llrp.AntennaConnected
+		if was, known := h.Antennas[id]; !known || was != connected {
+			if h.Antennas == nil {
+				h.Antennas = make(map[uint16]bool)
+			}
+			h.Antennas[id] = connected
+
+			a := AntennaDisconnected
+			if connected {
+				a = AntennaConnected
+			}
+			events = append(events, ReaderAlertEvent{Device: device, Alert: a, Timestamp: ts, AntennaID: id})
+		}
+	}
+
+	if ex := data.ReaderExceptionEvent; ex != nil {
+		e := ReaderAlertEvent{Device: device, Alert: ReaderException, Timestamp: ts, Message: ex.Message}
+		if ex.AntennaID != nil {
+			e.AntennaID = uint16(*ex.AntennaID)
+		}
+		h.LastException = &e
+		events = append(events, e)
+	}
+
+	if data.ReportBufferLevelWarningEvent != nil {
+		e := ReaderAlertEvent{Device: device, Alert: ReportBufferWarning, Timestamp: ts,
+			BufferLevel: uint8(*data.ReportBufferLevelWarningEvent)}
+		h.BufferWarnings++
+		h.LastBufferAlert = &e
+		events = append(events, e)
+	}
+
+	if data.ReportBufferOverflowErrorEvent != nil {
+		e := ReaderAlertEvent{Device: device, Alert: ReportBufferOverflow, Timestamp: ts}
+		h.BufferOverflows++
+		h.LastBufferAlert = &e
+		events = append(events, e)
+	}
+
+	if data.ROSpecEvent != nil {
+		h.ROSpecRunning = data.ROSpecEvent.Event == llrp.ROSpecStarted
+	}
+
+	if data.GPIEvent != nil {
+		if h.GPIs == nil {
+			h.GPIs = make(map[uint16]bool)
+		}
+		h.GPIs[data.GPIEvent.Port] = data.GPIEvent.Event
+	}
+
+	if data.ConnectionCloseEvent != nil {
+		h.LastDisconnected = ts
+		h.ROSpecRunning = false
+		if h.Connected {
+			h.Connected = false
+			events = append(events, ReaderAlertEvent{Device: device, Alert: ReaderDisconnected, Timestamp: ts})
+		}
+	}
+
+	return events
+}
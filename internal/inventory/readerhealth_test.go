@@ -0,0 +1,116 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+)
+
+func notification(data llrp.ReaderEventNotificationData) *llrp.ReaderEventNotification {
+	return &llrp.ReaderEventNotification{ReaderEventNotificationData: data}
+}
+
+func alertTypes(events []Event) []ReaderAlert {
+	var alerts []ReaderAlert
+	for _, e := range events {
+		alerts = append(alerts, e.(ReaderAlertEvent).Alert)
+	}
+	return alerts
+}
+
+func TestHealthMonitor(t *testing.T) {
+	const device = "reader"
+	now := time.Now()
+	hm := NewHealthMonitor()
+
+	_, ok := hm.Health(device)
+	assert.False(t, ok)
+
+	connected := llrp.ConnectionAttemptEvent(llrp.ConnSuccess)
+	events := hm.HandleNotification(device, notification(llrp.ReaderEventNotificationData{
+		UTCTimestamp:           llrp.UTCTimestamp(now.UnixMicro()),
+		ConnectionAttemptEvent: &connected,
+	}), now)
+	require.Equal(t, []ReaderAlert{ReaderConnected}, alertTypes(events))
+	assert.Equal(t, now.UnixMilli(), events[0].(ReaderAlertEvent).Timestamp)
+
+	// repeated states don't generate alerts
+	events = hm.HandleNotification(device, notification(llrp.ReaderEventNotificationData{
+		ConnectionAttemptEvent: &connected,
+	}), now)
+	assert.Empty(t, events)
+
+	antenna := &llrp.AntennaEvent{Event: llrp.AntennaDisconnected, AntennaID: 2}
+	events = hm.HandleNotification(device, notification(llrp.ReaderEventNotificationData{AntennaEvent: antenna}), now)
+	require.Equal(t, []ReaderAlert{AntennaDisconnected}, alertTypes(events))
+	assert.Equal(t, uint16(2), events[0].(ReaderAlertEvent).AntennaID)
+	events = hm.HandleNotification(device, notification(llrp.ReaderEventNotificationData{AntennaEvent: antenna}), now)
+	assert.Empty(t, events)
+
+	// every exception and buffer problem generates an alert
+	level := llrp.ReportBufferLevelWarningEvent(90)
+	events = hm.HandleNotification(device, notification(llrp.ReaderEventNotificationData{
+		ReaderExceptionEvent:           &llrp.ReaderExceptionEvent{Message: "fault"},
+		ReportBufferLevelWarningEvent:  &level,
+		ReportBufferOverflowErrorEvent: &llrp.ReportBufferOverflowErrorEvent{},
+	}), now)
+	assert.Equal(t, []ReaderAlert{ReaderException, ReportBufferWarning, ReportBufferOverflow}, alertTypes(events))
+
+	// ROSpec and GPI changes are tracked without alerts
+	events = hm.HandleNotification(device, notification(llrp.ReaderEventNotificationData{
+		ROSpecEvent: &llrp.ROSpecEvent{Event: llrp.ROSpecStarted},
+		GPIEvent:    &llrp.GPIEvent{Port: 1, Event: true},
+	}), now)
+	assert.Empty(t, events)
+
+	later := now.Add(time.Minute)
+	hm.ReportReceived(device, later)
+
+	h, ok := hm.Health(device)
+	require.True(t, ok)
+	assert.True(t, h.Connected)
+	assert.True(t, h.ROSpecRunning)
+	assert.Equal(t, map[uint16]bool{2: false}, h.Antennas)
+	assert.Equal(t, map[uint16]bool{1: true}, h.GPIs)
+	require.NotNil(t, h.LastException)
+	assert.Equal(t, "fault", h.LastException.Message)
+	assert.Equal(t, uint(1), h.BufferWarnings)
+	assert.Equal(t, uint(1), h.BufferOverflows)
+	assert.Equal(t, ReportBufferOverflow, h.LastBufferAlert.Alert)
+	assert.Equal(t, later.UnixMilli(), h.LastReport)
+
+	events = hm.HandleNotification(device, notification(llrp.ReaderEventNotificationData{
+		ConnectionCloseEvent: &llrp.ConnectionCloseEvent{},
+	}), later)
+	require.Equal(t, []ReaderAlert{ReaderDisconnected}, alertTypes(events))
+
+	h, _ = hm.Health(device)
+	assert.False(t, h.Connected)
+	assert.False(t, h.ROSpecRunning)
+	assert.Equal(t, later.UnixMilli(), h.LastDisconnected)
+}
+
+func TestHealthMonitor_SetConnected(t *testing.T) {
+	hm := NewHealthMonitor()
+	hm.SetConnected("reader", time.Now())
+
+	h, ok := hm.Health("reader")
+	require.True(t, ok)
+	assert.True(t, h.Connected)
+
+	// a reader that's already connected doesn't generate an alert
+	connected := llrp.ConnectionAttemptEvent(llrp.ConnSuccess)
+	events := hm.HandleNotification("reader", notification(llrp.ReaderEventNotificationData{
+		ConnectionAttemptEvent: &connected,
+	}), time.Now())
+	assert.Empty(t, events)
+}
@@ -10,6 +10,9 @@ import (
 type ReportInfo struct {
 	DeviceName  string
 	OriginNanos int64
+	// ReadNames maps the OpSpecIDs of the configured memory reads to their names.
+	// If it's empty, any read result is assumed to be the tag's TID.
+	ReadNames map[uint16]string
 
 	offsetMicros int64
 	// referenceTimestamp is the same as OriginNanos, but converted to milliseconds
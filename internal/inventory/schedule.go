@@ -0,0 +1,269 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+)
+
+// ScheduleAction is what a Schedule does when it fires.
+type ScheduleAction string
+
+const (
+	// ScheduleStart starts the readers, first applying the Schedule's Behavior, if it has one.
+	ScheduleStart ScheduleAction = "Start"
+	// ScheduleStop stops the readers.
+	ScheduleStop ScheduleAction = "Stop"
+	// ScheduleBehavior applies the Schedule's Behavior without starting or stopping the readers.
+	ScheduleBehavior ScheduleAction = "Behavior"
+)
+
+// Schedule is a rule to start or stop the readers, or change their Behavior,
+// at times matching its Cron expression, in the service's local time zone.
+type Schedule struct {
+	Name string `json:"name"`
+	// Cron is a standard five field cron expression: minute, hour, day of month, month, and day of week.
+	// Each field may be "*", or a comma-separated list of values or ranges, each with an optional step,
+	// such as "0 6 * * 1-5" or "*/15 8-20 * * *".
+	Cron     string         `json:"cron"`
+	Action   ScheduleAction `json:"action"`
+	Behavior *llrp.Behavior `json:"behavior,omitempty"`
+	// Group is the reader group to which the Schedule applies.
+	// If it's empty, Start and Stop apply to every group,
+	// and Behaviors apply to the default group.
+	Group string `json:"group,omitempty"`
+	// Disabled schedules never fire.
+	Disabled bool `json:"disabled,omitempty"`
+}
+
+// ScheduleFiredType and ScheduleFailedType define events generated when a Schedule fires,
+// depending on whether its action succeeded.
+const (
+	ScheduleFiredType  EventType = "ScheduleFired"
+	ScheduleFailedType EventType = "ScheduleFailed"
+)
+
+// ScheduleFiredEvent is generated when a Schedule fires and its action succeeds.
+type ScheduleFiredEvent struct {
+	Name   string         `json:"name"`
+	Action ScheduleAction `json:"action"`
+	// Timestamp is the time at which the Schedule fired (Unix Epoch milliseconds).
+	Timestamp int64 `json:"timestamp"`
+}
+
+// ScheduleFailedEvent is generated when a Schedule fires but its action fails.
+type ScheduleFailedEvent struct {
+	ScheduleFiredEvent
+	Error string `json:"error"`
+}
+
+// OfType for ScheduleFiredEvent returns ScheduleFiredType
+func (s ScheduleFiredEvent) OfType() EventType {
+	return ScheduleFiredType
+}
+
+// OfType for ScheduleFailedEvent returns ScheduleFailedType
+func (s ScheduleFailedEvent) OfType() EventType {
+	return ScheduleFailedType
+}
+
+// ErrInvalidSchedule is returned when a Schedule cannot be used.
+var ErrInvalidSchedule = errors.New("invalid schedule")
+
+// ValidateSchedules returns nil if every Schedule is valid and has a unique name,
+// or the first validation error it encounters.
+func ValidateSchedules(schedules []Schedule) error {
+	names := make(map[string]struct{}, len(schedules))
+	for _, s := range schedules {
+		if err := s.Validate(); err != nil {
+			return err
+		}
+		if _, ok := names[s.Name]; ok {
+			return fmt.Errorf("schedule name %q is used more than once: %w", s.Name, ErrInvalidSchedule)
+		}
+		names[s.Name] = struct{}{}
+	}
+	return nil
+}
+
+// Validate returns nil if the Schedule is valid.
+// It doesn't check whether its Behavior is valid for any particular reader.
+func (s Schedule) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("schedule has no name: %w", ErrInvalidSchedule)
+	}
+
+	if _, err := ParseCron(s.Cron); err != nil {
+		return fmt.Errorf("schedule %q: %w", s.Name, err)
+	}
+
+	switch s.Action {
+	case ScheduleStart, ScheduleStop:
+	case ScheduleBehavior:
+		if s.Behavior == nil {
+			return fmt.Errorf("schedule %q has action %q, but no behavior: %w",
+				s.Name, s.Action, ErrInvalidSchedule)
+		}
+	default:
+		return fmt.Errorf("schedule %q action must be one of %q, %q, or %q, not %q: %w",
+			s.Name, ScheduleStart, ScheduleStop, ScheduleBehavior, s.Action, ErrInvalidSchedule)
+	}
+
+	return nil
+}
+
+// CronSpec is a parsed cron expression.
+type CronSpec struct {
+	minute, hour, dom, month, dow uint64
+	// domAny and dowAny are true if the day of month or day of week is "*".
+	// If only one is restricted, days must match it; if both are, days may match either.
+	domAny, dowAny bool
+}
+
+type cronField struct {
+	name     string
+	min, max int
+}
+
+var cronFields = [5]cronField{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day of month", 1, 31},
+	{"month", 1, 12},
+	{"day of week", 0, 7}, // both 0 and 7 are Sunday
+}
+
+// ParseCron parses a five field cron expression.
+func ParseCron(expr string) (CronSpec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return CronSpec{}, fmt.Errorf("cron expression %q must have %d fields: %w",
+			expr, len(cronFields), ErrInvalidSchedule)
+	}
+
+	var sets [5]uint64
+	for i, f := range fields {
+		set, err := parseCronField(f, cronFields[i])
+		if err != nil {
+			return CronSpec{}, err
+		}
+		sets[i] = set
+	}
+
+	// fold Sunday=7 into Sunday=0
+	if sets[4]&(1<<7) != 0 {
+		sets[4] = sets[4]&^(1<<7) | 1
+	}
+
+	return CronSpec{
+		minute: sets[0], hour: sets[1], dom: sets[2], month: sets[3], dow: sets[4],
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}, nil
+}
+
+// parseCronField returns the set of values matched by the field as a bitset.
+func parseCronField(field string, cf cronField) (uint64, error) {
+	invalid := func(why string) error {
+		return fmt.Errorf("cron %s field %q %s: %w", cf.name, field, why, ErrInvalidSchedule)
+	}
+
+	var set uint64
+	for _, part := range strings.Split(field, ",") {
+		rng, stepStr, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			var err error
+			if step, err = strconv.Atoi(stepStr); err != nil || step < 1 {
+				return 0, invalid("has an invalid step")
+			}
+		}
+
+		lo, hi := cf.min, cf.max
+		if rng != "*" {
+			loStr, hiStr, isRange := strings.Cut(rng, "-")
+			var err error
+			if lo, err = strconv.Atoi(loStr); err != nil {
+				return 0, invalid("has an invalid value")
+			}
+			hi = lo
+			if isRange {
+				if hi, err = strconv.Atoi(hiStr); err != nil {
+					return 0, invalid("has an invalid range")
+				}
+			} else if hasStep {
+				hi = cf.max // "5/10" means every 10 starting at 5
+			}
+		}
+
+		if lo < cf.min || hi > cf.max || lo > hi {
+			return 0, invalid(fmt.Sprintf("is outside of [%d, %d]", cf.min, cf.max))
+		}
+
+		for v := lo; v <= hi; v += step {
+			set |= 1 << v
+		}
+	}
+
+	return set, nil
+}
+
+func (c CronSpec) matchesDay(t time.Time) bool {
+	domMatch := c.dom&(1<<t.Day()) != 0
+	dowMatch := c.dow&(1<<int(t.Weekday())) != 0
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dowMatch
+	case c.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// Next returns the first time after t that matches the cron expression,
+// in t's location, or the zero time if there isn't one within 5 years
+// (for instance, because it only matches February 30th).
+func (c CronSpec) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if c.month&(1<<int(t.Month())) == 0 {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchesDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.hour&(1<<t.Hour()) == 0 {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if c.minute&(1<<t.Minute()) == 0 {
+			// skip directly to the next matching minute in this hour, if there is one
+			if rest := c.minute >> t.Minute(); rest != 0 {
+				t = t.Add(time.Duration(bits.TrailingZeros64(rest)) * time.Minute)
+			} else {
+				t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			}
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
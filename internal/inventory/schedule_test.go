@@ -0,0 +1,104 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package inventory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+)
+
+func TestCronSpec_Next(t *testing.T) {
+	// Wednesday
+	from := time.Date(2026, time.March, 4, 10, 17, 30, 0, time.UTC)
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"* * * * *", time.Date(2026, time.March, 4, 10, 18, 0, 0, time.UTC)},
+		{"0 6 * * *", time.Date(2026, time.March, 5, 6, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, time.March, 4, 10, 30, 0, 0, time.UTC)},
+		{"5/20 * * * *", time.Date(2026, time.March, 4, 10, 25, 0, 0, time.UTC)},
+		{"0 22 * * 1-5", time.Date(2026, time.March, 4, 22, 0, 0, 0, time.UTC)},
+		{"0 8 * * 0", time.Date(2026, time.March, 8, 8, 0, 0, 0, time.UTC)},
+		{"0 8 * * 7", time.Date(2026, time.March, 8, 8, 0, 0, 0, time.UTC)},
+		{"30 9 1 * *", time.Date(2026, time.April, 1, 9, 30, 0, 0, time.UTC)},
+		{"0 0 1,15 6 *", time.Date(2026, time.June, 1, 0, 0, 0, 0, time.UTC)},
+		// with both days restricted, either may match
+		{"0 0 15 * 5", time.Date(2026, time.March, 6, 0, 0, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2028, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		{"0 0 30 2 *", time.Time{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.expr, func(t *testing.T) {
+			c, err := ParseCron(tc.expr)
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, c.Next(from))
+		})
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"1-a * * * *",
+	} {
+		_, err := ParseCron(expr)
+		assert.ErrorIs(t, err, ErrInvalidSchedule, expr)
+	}
+}
+
+func TestValidateSchedules(t *testing.T) {
+	deep := &llrp.Behavior{ScanType: llrp.ScanDeep}
+
+	tests := []struct {
+		name      string
+		schedules []Schedule
+		wantErr   bool
+	}{
+		{"none", nil, false},
+		{"valid", []Schedule{
+			{Name: "open", Cron: "0 6 * * *", Action: ScheduleStart, Behavior: deep},
+			{Name: "day", Cron: "15 6 * * *", Action: ScheduleBehavior, Behavior: &llrp.Behavior{}},
+			{Name: "close", Cron: "0 22 * * *", Action: ScheduleStop},
+		}, false},
+		{"no name", []Schedule{{Cron: "0 6 * * *", Action: ScheduleStop}}, true},
+		{"duplicate names", []Schedule{
+			{Name: "a", Cron: "0 6 * * *", Action: ScheduleStart},
+			{Name: "a", Cron: "0 22 * * *", Action: ScheduleStop},
+		}, true},
+		{"bad cron", []Schedule{{Name: "a", Cron: "0 6 * *", Action: ScheduleStop}}, true},
+		{"bad action", []Schedule{{Name: "a", Cron: "0 6 * * *", Action: "Pause"}}, true},
+		{"missing behavior", []Schedule{{Name: "a", Cron: "0 6 * * *", Action: ScheduleBehavior}}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateSchedules(tc.schedules)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidSchedule)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -13,8 +13,34 @@ type StaticTag struct {
 	// TID is commonly referred to as Tag ID or Transponder ID. It is a unique number written to
 	// every RFID tag by the manufacturer and is non-writable.
 	TID string `json:"tid"`
+	// TIDs is the list of distinct TIDs observed with this tag's EPC, in the order they were first seen.
+	TIDs []string `json:"tids,omitempty"`
+	// TIDConflict is true if this tag's EPC has been observed with more than one TID,
+	// which indicates a cloned, counterfeit, or mis-encoded tag.
+	TIDConflict bool `json:"tid_conflict"`
+	// Memory holds the most recent hex-encoded results of the configured tag memory reads,
+	// keyed by the name of the read.
+	Memory map[string]string `json:"memory,omitempty"`
+	// Locks maps tag memory regions to the lock privilege most recently applied to them
+	// by a successful lock operation.
+	Locks map[string]string `json:"locks,omitempty"`
 	// Location keeps track of the tag's current location in the form of Device and Antenna combo.
 	Location Location `json:"location"`
+	// LocationHistory is the list of the tag's most recent distinct locations since it last arrived,
+	// oldest first, including its current Location.
+	LocationHistory []string `json:"location_history,omitempty"`
+	// Position is the tag's most recent position reported by a gateway in Location mode, if any.
+	Position *Position `json:"position,omitempty"`
+	// Direction is the tag's most recent travel reported by a gateway in Direction mode, if any.
+	Direction *Direction `json:"direction,omitempty"`
+	// DeparturePaused is true if the tag can't currently depart because its location is unavailable,
+	// reading is stopped there, or a GPISignal gates its departures.
+	DeparturePaused bool `json:"departure_paused,omitempty"`
+	// FrozenAt is when the tag's departure clock was paused (Unix Epoch milliseconds), if it is.
+	FrozenAt int64 `json:"frozen_at,omitempty"`
+	// DepartureRef, if later than LastRead, is used in its place to determine
+	// whether the tag has Departed; it accounts for time its location was unavailable.
+	DepartureRef int64 `json:"departure_ref,omitempty"`
 	// LocationAlias returns the string version of the location adjusted for any user-provided aliases.
 	LocationAlias string `json:"location_alias"`
 	// LastRead keeps track of the last time the tag was seen by any reader/antenna
@@ -39,21 +65,36 @@ type StaticTag struct {
 type StaticTagStats struct {
 	LastRead int64   `json:"last_read"`
 	MeanRSSI float64 `json:"mean_rssi"`
+	// PhaseAngle is the most recent RF phase angle (radians), if readers report it.
+	PhaseAngle *float64 `json:"phase_angle,omitempty"`
+	// MeanDoppler is the rolling average RF Doppler frequency (Hz), if readers report it;
+	// it's near zero for stationary tags.
+	MeanDoppler *float64 `json:"mean_doppler,omitempty"`
 }
 
 // asTagPtr converts a StaticTag back to a Tag pointer for use in restoring inventory.
+// The Tag gets its own copies of the StaticTag's slices and maps, since it modifies them.
 // It will also restore a basic view of the per-location stats by setting the last read
 // timestamp and a single RSSI value which was the previously computed rolling average.
 func (s StaticTag) asTagPtr() *Tag {
 	t := &Tag{
-		EPC:          s.EPC,
-		TID:          s.TID,
-		Location:     s.Location,
-		LastRead:     s.LastRead,
-		LastDeparted: s.LastDeparted,
-		LastArrived:  s.LastArrived,
-		state:        s.State,
-		statsMap:     make(map[string]*tagStats),
+		EPC:             s.EPC,
+		TID:             s.TID,
+		TIDs:            append([]string(nil), s.TIDs...),
+		TIDConflict:     s.TIDConflict,
+		Memory:          copyStrMap(s.Memory),
+		Locks:           copyStrMap(s.Locks),
+		Location:        s.Location,
+		LocationHistory: append([]string(nil), s.LocationHistory...),
+		Position:        s.Position,
+		Direction:       s.Direction,
+		LastRead:        s.LastRead,
+		LastDeparted:    s.LastDeparted,
+		LastArrived:     s.LastArrived,
+		state:           s.State,
+		frozenAt:        s.FrozenAt,
+		departureRef:    s.DepartureRef,
+		statsMap:        make(map[string]*tagStats),
 	}
 
 	// fill in any cached tag stats. this just adds the mean rssi as a single value,
@@ -63,6 +104,12 @@ func (s StaticTag) asTagPtr() *Tag {
 		tagStats := t.getStats(location)
 		tagStats.lastRead = stats.LastRead
 		tagStats.rssiDbm.AddValue(stats.MeanRSSI)
+		if stats.PhaseAngle != nil {
+			tagStats.updatePhase(*stats.PhaseAngle)
+		}
+		if stats.MeanDoppler != nil {
+			tagStats.updateDoppler(*stats.MeanDoppler)
+		}
 	}
 
 	return t
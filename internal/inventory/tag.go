@@ -29,8 +29,27 @@ type Tag struct {
 	// TID is commonly referred to as Tag ID or Transponder ID. It is a unique number written to
 	// every RFID tag by the manufacturer and is non-writable.
 	TID string
+	// TIDs is the list of distinct TIDs observed with this tag's EPC, in the order they were first seen,
+	// limited to the most recent maxTIDs.
+	TIDs []string
+	// TIDConflict is true if this tag's EPC has been observed with more than one TID,
+	// which indicates a cloned, counterfeit, or mis-encoded tag.
+	TIDConflict bool
+	// Memory holds the most recent hex-encoded results of the configured tag memory reads,
+	// keyed by the name of the read.
+	Memory map[string]string
+	// Locks maps tag memory regions to the lock privilege most recently applied to them
+	// by a successful lock operation.
+	Locks map[string]string
 	// Location keeps track of the tag's current location in the form of Device and Antenna combo.
 	Location Location
+	// LocationHistory is the list of the tag's most recent distinct locations since it last arrived,
+	// oldest first, including its current Location.
+	LocationHistory []string
+	// Position is the tag's most recent position reported by a gateway in Location mode, if any.
+	Position *Position
+	// Direction is the tag's most recent travel reported by a gateway in Direction mode, if any.
+	Direction *Direction
 	// LastRead keeps track of the last time the tag was seen by any reader/antenna
 	// (Unix Epoch milliseconds). This value is used to determine AgeOut as
 	// well as Departed events.
@@ -44,6 +63,12 @@ type Tag struct {
 
 	// state is the current state of the tag (Present, Departed, Unknown)
 	state TagState
+	// frozenAt is when the tag's location became unavailable (Unix Epoch milliseconds),
+	// or 0 if its departure clock isn't frozen.
+	frozenAt int64
+	// departureRef, if later than LastRead, is used in its place to determine
+	// whether the tag has Departed; it accounts for time its location was unavailable.
+	departureRef int64
 	// statsMap keeps track of read statistics on a per-antenna basis in order to apply
 	// tag location algorithms against.
 	statsMap map[string]*tagStats
@@ -77,6 +102,82 @@ func (tag *Tag) setStateAt(newState TagState, timestamp int64) {
 	tag.state = newState
 }
 
+// maxTIDs is the most TIDs a tag keeps, so that an EPC read with ever-changing TIDs
+// (e.g., a tag cloned many times) doesn't grow its list without bound.
+const maxTIDs = 8
+
+// addTID records a TID observed with this tag's EPC,
+// discarding the oldest TIDs to keep at most maxTIDs.
+// It returns true if the TID was not seen before and the EPC already had a different TID,
+// in which case the tag is also flagged with a TIDConflict.
+func (tag *Tag) addTID(tid string) (conflict bool) {
+	for _, known := range tag.TIDs {
+		if known == tid {
+			return false
+		}
+	}
+
+	conflict = len(tag.TIDs) > 0
+	tag.TIDs = append(tag.TIDs, tid)
+	if over := len(tag.TIDs) - maxTIDs; over > 0 {
+		tag.TIDs = append(tag.TIDs[:0], tag.TIDs[over:]...)
+	}
+	if conflict {
+		tag.TIDConflict = true
+	}
+	return conflict
+}
+
+// addLocationHistory appends the tag's current location to its LocationHistory
+// if it differs from the most recent entry,
+// discarding the oldest entries to keep at most maxSize.
+func (tag *Tag) addLocationHistory(maxSize int) {
+	if tag.Location.IsEmpty() {
+		return
+	}
+
+	location := tag.Location.String()
+	if n := len(tag.LocationHistory); n > 0 && tag.LocationHistory[n-1] == location {
+		return
+	}
+
+	tag.LocationHistory = append(tag.LocationHistory, location)
+	if over := len(tag.LocationHistory) - maxSize; over > 0 {
+		tag.LocationHistory = append(tag.LocationHistory[:0], tag.LocationHistory[over:]...)
+	}
+}
+
+// setMemory records the hex-encoded data read from the named memory region.
+func (tag *Tag) setMemory(name, data string) {
+	if tag.Memory == nil {
+		tag.Memory = make(map[string]string)
+	}
+	tag.Memory[name] = data
+}
+
+// copyStrMap returns a copy of the map, or nil if it's empty.
+func copyStrMap(m map[string]string) map[string]string {
+	if len(m) == 0 {
+		return nil
+	}
+
+	c := make(map[string]string, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
+
+// baseEvent returns a BaseEvent for the tag with the given timestamp.
+func (tag *Tag) baseEvent(timestamp int64) BaseEvent {
+	return BaseEvent{
+		EPC:       tag.EPC,
+		TID:       tag.TID,
+		Timestamp: timestamp,
+		Memory:    copyStrMap(tag.Memory),
+	}
+}
+
 func (tag *Tag) resetStats() {
 	tag.statsMu.Lock()
 	defer tag.statsMu.Unlock()
@@ -16,6 +16,9 @@ import (
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/models"
 )
 
+// tidReadName is the name of the memory read whose result is used as a tag's TID.
+const tidReadName = "tid"
+
 type processorConfig struct {
 	profile mobilityProfile
 	aliases map[string]string
@@ -23,6 +26,19 @@ type processorConfig struct {
 	departedThresholdSeconds uint
 	ageOutHours              uint
 	adjustLastReadOnByOrigin bool
+	// aliasChangeEvent is the type of event generated when an alias update
+	// changes the alias of a Present tag's location, or empty if none should be.
+	aliasChangeEvent EventType
+	// exitLocations is the set of exit locations, by default name or alias.
+	exitLocations      map[string]struct{}
+	exitTimeoutSeconds uint
+	// posLocations is the set of point of sale locations, by default name or alias.
+	posLocations        map[string]struct{}
+	locationHistorySize int
+	// stoppedMode determines how departures are handled while reading is stopped.
+	stoppedMode string
+	// gpiSignals maps GPI ports to their GPISignals.
+	gpiSignals map[gpiPort]GPISignal
 }
 
 // TagProcessor holds the current inventory data and processes incoming tag read data
@@ -30,18 +46,34 @@ type TagProcessor struct {
 	lc        logger.LoggingClient
 	inventory map[string]*Tag
 	config    processorConfig
+	// unavailable maps readers (by device name) and antennas (by location)
+	// that can't currently read tags to when they became unavailable.
+	unavailable map[string]int64
+	// stoppedSince is when reading was stopped, or 0 if it isn't.
+	stoppedSince int64
+	// idle maps readers waiting for a trigger to start reading to when they became idle.
+	idle map[string]int64
+	// gpis holds the most recently reported state of each GPISignal's port.
+	gpis map[gpiPort]bool
+	// restoredFrozen is true if tags were restored with frozen departure clocks
+	// and reading hasn't started since.
+	restoredFrozen bool
 }
 
 // NewTagProcessor creates a tag processor and pre-loads its mobility profile
 func NewTagProcessor(lc logger.LoggingClient, cfg ServiceConfig, tags []StaticTag) *TagProcessor {
 	tp := &TagProcessor{
-		lc:        lc,
-		inventory: make(map[string]*Tag),
+		lc:          lc,
+		inventory:   make(map[string]*Tag),
+		unavailable: make(map[string]int64),
+		idle:        make(map[string]int64),
+		gpis:        make(map[gpiPort]bool),
 	}
 	tp.UpdateConfig(cfg.AppCustom)
 
 	for _, t := range tags {
 		tp.inventory[t.EPC] = t.asTagPtr()
+		tp.restoredFrozen = tp.restoredFrozen || t.FrozenAt != 0
 	}
 
 	return tp
@@ -54,25 +86,145 @@ func (tp *TagProcessor) isDebugLogging() bool {
 // UpdateConfig takes in a ConsulConfig raw config object and converts it into a locally cached
 // version that is understood by the TagProcessor. It also generates the correct mobility profile
 // based on the supplied values, and the alias map as well.
-func (tp *TagProcessor) UpdateConfig(cfg CustomConfig) {
+//
+// If the update changes the alias of any location in the inventory,
+// it returns an updated snapshot, along with an event for each Present tag at such a location,
+// according to the configured AliasChangeEvent.
+// It also returns an updated snapshot if the GPISignals change which readers' departures are gated.
+// If the GPISignals are invalid, it keeps the current ones.
+func (tp *TagProcessor) UpdateConfig(cfg CustomConfig) (events []Event, snapshot []StaticTag) {
 	as := cfg.AppSettings
 	profile := newMobilityProfile(as.MobilityProfileSlope, as.MobilityProfileThreshold, as.MobilityProfileHoldoffMillis)
 	aliases := cfg.Aliases
 	delete(aliases, "")
 
+	var aliasChangeEvent EventType
+	switch as.AliasChangeEvent {
+	case string(MovedType):
+		aliasChangeEvent = MovedType
+	case aliasChangeNone:
+	default:
+		aliasChangeEvent = LocationRenamedType
+	}
+
+	historySize := int(as.LocationHistorySize)
+	if historySize < 1 {
+		historySize = 1
+	}
+
+	signals, err := newGPISignalSet(cfg.GPISignals)
+	if err != nil {
+		tp.lc.Error("Invalid GPI signals in configuration; keeping the current signals.", "error", err.Error())
+		signals = tp.config.gpiSignals
+	}
+
+	oldAliases := tp.config.aliases
+	wasGated := tp.gatedDevices()
 	tp.config = processorConfig{
 		adjustLastReadOnByOrigin: as.AdjustLastReadOnByOrigin,
 		departedThresholdSeconds: as.DepartedThresholdSeconds,
 		ageOutHours:              as.AgeOutHours,
 		profile:                  profile,
 		aliases:                  aliases,
+		aliasChangeEvent:         aliasChangeEvent,
+		exitLocations:            newLocationSet(cfg.ExitLocations),
+		exitTimeoutSeconds:       as.ExitTimeoutSeconds,
+		posLocations:             newLocationSet(cfg.POSLocations),
+		locationHistorySize:      historySize,
+		stoppedMode:              as.StoppedDepartureMode,
+		gpiSignals:               signals,
+	}
+	if tp.config.stoppedMode == "" {
+		tp.config.stoppedMode = StoppedDeparturePause
+	}
+	if tp.config.stoppedMode == StoppedDepartureContinue {
+		tp.endStopped(time.Now().UnixMilli())
 	}
+
+	nowMs := time.Now().UnixMilli()
+	regated := 0
+	for device := range tp.gatedDevices() {
+		_, gated := wasGated[device]
+		regated += tp.regate(device, gated, nowMs)
+		delete(wasGated, device)
+	}
+	for device := range wasGated {
+		regated += tp.regate(device, true, nowMs)
+	}
+
+	events, changed := tp.reevaluateAliases(oldAliases)
+	if !changed && regated == 0 {
+		return nil, nil
+	}
+	// the snapshot includes each tag's location alias, so it's updated even without events
+	return events, tp.snapshot()
 }
 
-// ProcessReport takes an incoming ROAccessReport and processes each TagReportData.
+// reevaluateAliases compares the old alias map to the current one
+// and returns an event for every Present tag whose location alias is now different.
+// The returned bool is true if the alias of any location changed.
+func (tp *TagProcessor) reevaluateAliases(oldAliases map[string]string) (events []Event, changed bool) {
+	if len(tp.inventory) == 0 {
+		return nil, false // nothing to re-evaluate, e.g. when the processor is first created
+	}
+
+	renamed := make(map[string]struct{})
+	for location := range oldAliases {
+		if aliasOf(oldAliases, location) != tp.getAlias(location) {
+			renamed[location] = struct{}{}
+		}
+	}
+	for location := range tp.config.aliases {
+		if aliasOf(oldAliases, location) != tp.getAlias(location) {
+			renamed[location] = struct{}{}
+		}
+	}
+
+	if len(renamed) == 0 {
+		return nil, false
+	}
+	tp.lc.Info(fmt.Sprintf("Alias update renamed %d location(s).", len(renamed)))
+
+	nowMs := time.Now().UnixMilli()
+	for _, tag := range tp.inventory {
+		if tag.state != Present {
+			continue
+		}
+
+		location := tag.Location.String()
+		if _, ok := renamed[location]; !ok {
+			continue
+		}
+
+		base := tag.baseEvent(nowMs)
+		oldAlias, newAlias := aliasOf(oldAliases, location), tp.getAlias(location)
+		tp.lc.Debug("Tag location alias changed.", "epc", tag.EPC, "old", oldAlias, "new", newAlias)
+
+		switch tp.config.aliasChangeEvent {
+		case LocationRenamedType:
+			events = append(events, LocationRenamedEvent{
+				BaseEvent:   base,
+				OldLocation: oldAlias,
+				NewLocation: newAlias,
+			})
+		case MovedType:
+			events = append(events, MovedEvent{
+				BaseEvent:   base,
+				OldLocation: oldAlias,
+				NewLocation: newAlias,
+			})
+		}
+	}
+
+	return events, true
+}
+
+// ProcessReport takes an incoming ROAccessReport and processes each TagReportData,
+// along with any tag information reported by Impinj gateways.
 // For every TagReportData it will update the corresponding tag our in-memory tag database
 // based on the latest information.
 func (tp *TagProcessor) ProcessReport(r *llrp.ROAccessReport, info ReportInfo) (events []Event, snapshot []StaticTag) {
+	tagInfos := r.ExtractImpinjTagInformation()
 	if tp.config.adjustLastReadOnByOrigin {
 		// offsetMicros is an adjustment of timestamps
 		// based on when the device service first saw the message
@@ -89,24 +241,167 @@ func (tp *TagProcessor) ProcessReport(r *llrp.ROAccessReport, info ReportInfo) (
 				lastSeenMicros = int64(*rt.LastSeenUTC) // #nosec G115
 			}
 		}
+		for i := range tagInfos {
+			lastSeenMicros = max(lastSeenMicros, int64(tagInfos[i].LastSeenUTC())) // #nosec G115
+		}
 		if lastSeenMicros > 0 {
 			// divide originNanos by 1000 to get to micros
 			info.offsetMicros = (info.OriginNanos / 1000) - lastSeenMicros
 		}
 	}
 
+	// a reader that's reporting tags is evidently available
+	if _, ok := tp.unavailable[info.DeviceName]; ok {
+		tp.setAvailable(info.DeviceName, time.Now().UnixMilli())
+	}
+
+	// while a GPISignal gates the reader's associations,
+	// it can only read tags already located at it
+	gated := tp.gatesAssociations(info.DeviceName)
+	ignored := 0
 	for i := range r.TagReportData {
-		if event := tp.processData(&r.TagReportData[i], info); event != nil {
-			events = append(events, event)
+		if gated && !tp.isAssociated(r.TagReportData[i].EPCAsHex(), info.DeviceName) {
+			ignored++
+			continue
+		}
+		events = append(events, tp.processData(&r.TagReportData[i], info)...)
+	}
+	for i := range tagInfos {
+		if gated && !tp.isAssociated(hex.EncodeToString(tagInfos[i].EPC), info.DeviceName) {
+			ignored++
+			continue
+		}
+		events = append(events, tp.processTagInformation(&tagInfos[i], info)...)
+	}
+	if ignored != 0 {
+		tp.lc.Debug("Ignored reads gated by GPI signal.", "device", info.DeviceName, "reads", ignored)
+	}
+	return events, tp.snapshot()
+}
+
+// ProcessAccessResults updates the inventory according to the results of
+// tag access operations performed by the named device.
+//
+// Tags that were successfully killed are removed from the inventory,
+// and tags that were successfully locked have their lock state updated.
+// An event is generated for each such result,
+// even if the tag wasn't in the inventory.
+// Unsuccessful results and results for other operations are ignored.
+func (tp *TagProcessor) ProcessAccessResults(device string, results []llrp.AccessResult) (events []Event, snapshot []StaticTag) {
+	nowMs := time.Now().UnixMilli()
+	for _, res := range results {
+		if !res.Success {
+			continue
+		}
+
+		tag, exists := tp.inventory[res.EPC]
+		if !exists {
+			tag = NewTag(res.EPC)
+		}
+
+		var location string
+		if !tag.Location.IsEmpty() {
+			location = tp.getAlias(tag.Location.String())
+		}
+
+		switch res.Operation {
+		case llrp.AccessOpKill:
+			tp.lc.Info("Tag killed.", "epc", res.EPC, "device", device)
+			delete(tp.inventory, res.EPC)
+			events = append(events, TagKilledEvent{
+				BaseEvent:         tag.baseEvent(nowMs),
+				Device:            device,
+				LastKnownLocation: location,
+			})
+
+		case llrp.AccessOpLock:
+			tp.lc.Info("Tag locked.", "epc", res.EPC, "device", device)
+			locks := make(map[string]string, len(res.Locks))
+			for _, l := range res.Locks {
+				locks[l.Data] = l.Privilege
+			}
+
+			if exists {
+				if tag.Locks == nil {
+					tag.Locks = make(map[string]string, len(locks))
+				}
+				for data, priv := range locks {
+					tag.Locks[data] = priv
+				}
+			}
+
+			events = append(events, TagLockedEvent{
+				BaseEvent: tag.baseEvent(nowMs),
+				Device:    device,
+				Location:  location,
+				Locks:     locks,
+			})
 		}
 	}
+
+	if len(events) == 0 {
+		return nil, nil
+	}
 	return events, tp.snapshot()
 }
 
+// newLocationSet returns a set of the non-empty location names.
+func newLocationSet(locations []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(locations))
+	for _, location := range locations {
+		if location != "" {
+			set[location] = struct{}{}
+		}
+	}
+	return set
+}
+
+// inLocationSet returns true if the location name, or its alias, is in the set.
+func (tp *TagProcessor) inLocationSet(set map[string]struct{}, location string) bool {
+	if len(set) == 0 || location == "" {
+		return false
+	}
+
+	if _, ok := set[location]; ok {
+		return true
+	}
+	_, ok := set[tp.getAlias(location)]
+	return ok
+}
+
+// isExit returns true if the location, or its alias, is one of the exit locations.
+func (tp *TagProcessor) isExit(location Location) bool {
+	return !location.IsEmpty() && tp.inLocationSet(tp.config.exitLocations, location.String())
+}
+
+// tracksExplanations returns true if departures should be classified as explained or not,
+// which is the case when there's at least one exit or point of sale location.
+func (tp *TagProcessor) tracksExplanations() bool {
+	return len(tp.config.exitLocations) != 0 || len(tp.config.posLocations) != 0
+}
+
+// isExplainedDeparture returns true if any of the tag's recent locations
+// is an exit or point of sale location.
+func (tp *TagProcessor) isExplainedDeparture(tag *Tag) bool {
+	for _, location := range tag.LocationHistory {
+		if tp.inLocationSet(tp.config.exitLocations, location) ||
+			tp.inLocationSet(tp.config.posLocations, location) {
+			return true
+		}
+	}
+	return false
+}
+
 // getAlias returns the alias associated with a location if one has been defined,
 // otherwise it returns back the original location.
 func (tp *TagProcessor) getAlias(location string) string {
-	if alias, exists := tp.config.aliases[location]; exists && alias != "" {
+	return aliasOf(tp.config.aliases, location)
+}
+
+// aliasOf returns the alias of a location in the given alias map,
+// or the location itself if it has no (non-empty) alias.
+func aliasOf(aliases map[string]string, location string) string {
+	if alias, exists := aliases[location]; exists && alias != "" {
 		return alias
 	}
 	return location
@@ -121,13 +416,25 @@ func (tp *TagProcessor) snapshot() []StaticTag {
 		staticTag := StaticTag{
 			EPC:           tag.EPC,
 			TID:           tag.TID,
+			TIDs:          append([]string(nil), tag.TIDs...),
+			TIDConflict:   tag.TIDConflict,
+			Memory:        copyStrMap(tag.Memory),
+			Locks:         copyStrMap(tag.Locks),
 			Location:      tag.Location,
 			LocationAlias: tp.getAlias(tag.Location.String()),
-			LastRead:      tag.LastRead,
-			LastArrived:   tag.LastArrived,
-			LastDeparted:  tag.LastDeparted,
-			State:         tag.state,
-			StatsMap:      make(map[string]StaticTagStats, len(tag.statsMap)),
+			// the history is modified in place, so it must be copied
+			LocationHistory: append([]string(nil), tag.LocationHistory...),
+			// these are replaced rather than modified, so they can be shared
+			Position:        tag.Position,
+			Direction:       tag.Direction,
+			DeparturePaused: tag.frozenAt != 0,
+			FrozenAt:        tag.frozenAt,
+			DepartureRef:    tag.departureRef,
+			LastRead:        tag.LastRead,
+			LastArrived:     tag.LastArrived,
+			LastDeparted:    tag.LastDeparted,
+			State:           tag.state,
+			StatsMap:        make(map[string]StaticTagStats, len(tag.statsMap)),
 		}
 
 		// re-populate the stats map
@@ -135,10 +442,16 @@ func (tp *TagProcessor) snapshot() []StaticTag {
 			if stats.rssiCount() == 0 {
 				continue // skip empty
 			}
-			staticTag.StatsMap[loc] = StaticTagStats{
-				LastRead: stats.lastRead,
-				MeanRSSI: stats.rssiDbm.Mean(),
+			static := StaticTagStats{
+				LastRead:   stats.lastRead,
+				MeanRSSI:   stats.rssiDbm.Mean(),
+				PhaseAngle: stats.phaseRad,
+			}
+			if stats.dopplerHz.Len() != 0 {
+				doppler := stats.dopplerHz.Mean()
+				static.MeanDoppler = &doppler
 			}
+			staticTag.StatsMap[loc] = static
 		}
 
 		res = append(res, staticTag)
@@ -148,20 +461,15 @@ func (tp *TagProcessor) snapshot() []StaticTag {
 
 // processData processes an incoming TagReportData packet and updates the tag information and
 // device stats data structures.
-func (tp *TagProcessor) processData(rt *llrp.TagReportData, info ReportInfo) (event Event) {
-	var epc string
-	if len(rt.EPC96.EPC) > 0 {
-		epc = hex.EncodeToString(rt.EPC96.EPC)
-	} else {
-		epc = hex.EncodeToString(rt.EPCData.EPC)
-	}
-
+func (tp *TagProcessor) processData(rt *llrp.TagReportData, info ReportInfo) (events []Event) {
+	epc := rt.EPCAsHex()
 	tag, exists := tp.inventory[epc]
 	if !exists {
 		tag = NewTag(epc)
 		tp.inventory[epc] = tag
 	}
 	prevState, prevLoc := tag.state, tag.Location
+	var tidConflict bool
 
 	// Note: This must be deferred because the code following this defer block has many early-exit
 	// scenarios, however we need this deferred block to be run regardless. It is an anonymous
@@ -171,42 +479,49 @@ func (tp *TagProcessor) processData(rt *llrp.TagReportData, info ReportInfo) (ev
 		switch prevState {
 		case Unknown, Departed:
 			tag.setState(Present)
-			event = ArrivedEvent{
-				BaseEvent: BaseEvent{
-					EPC:       tag.EPC,
-					TID:       tag.TID,
-					Timestamp: tag.LastRead,
-				},
-				Location: tp.getAlias(tag.Location.String()),
-			}
+			// the history only covers the tag's current visit
+			tag.LocationHistory = nil
+			events = append(events, ArrivedEvent{
+				BaseEvent: tag.baseEvent(tag.LastRead),
+				Location:  tp.getAlias(tag.Location.String()),
+			})
 
 		case Present:
-			if prevLoc.IsEmpty() || prevLoc.Equals(tag.Location) {
-				break
+			if moved, ok := tp.movedEvent(tag, prevLoc); ok {
+				events = append(events, moved)
 			}
+		}
 
-			prevAlias := tp.getAlias(prevLoc.String())
-			curAlias := tp.getAlias(tag.Location.String())
-			if prevAlias == curAlias {
-				break // do not send event if the two locations share the same alias
-			}
-			event = MovedEvent{
-				BaseEvent: BaseEvent{
-					EPC:       tag.EPC,
-					TID:       tag.TID,
-					Timestamp: tag.LastRead,
-				},
-				OldLocation: prevAlias,
-				NewLocation: curAlias,
-			}
+		tag.addLocationHistory(tp.config.locationHistorySize)
+
+		if tidConflict {
+			tp.lc.Warn("EPC observed with multiple TIDs.", "epc", tag.EPC, "tids", fmt.Sprintf("%v", tag.TIDs))
+			events = append(events, TIDConflictEvent{
+				BaseEvent: tag.baseEvent(tag.LastRead),
+				TIDs:      append([]string(nil), tag.TIDs...),
+				Location:  tp.getAlias(tag.Location.String()),
+			})
 		}
 	}()
 
-	// todo: The following code assumes that if ReadDataAsHex returns ok, that the data contained
-	// 		 within is the TID (Tag ID). This is not always the case, but it is the only
-	//		 type we currently support for ReadOpSpec.
-	if tid, ok := rt.ReadDataAsHex(); ok {
-		tag.TID = tid
+	if data, ok := rt.ReadDataAsHex(); ok {
+		// Without configured memory reads, assume the read's result is the TID,
+		// since that's the only ReadOpSpec this service historically supported.
+		name := tidReadName
+		if len(info.ReadNames) != 0 {
+			name = info.ReadNames[rt.C1G2ReadOpSpecResult.OpSpecID]
+		}
+
+		switch name {
+		case "":
+			tp.lc.Debugf("Ignoring read result for unknown OpSpecID %d.", rt.C1G2ReadOpSpecResult.OpSpecID)
+		case tidReadName:
+			tidConflict = tag.addTID(data)
+			tag.TID = data
+			tag.setMemory(name, data)
+		default:
+			tag.setMemory(name, data)
+		}
 	}
 
 	hasTimestamp := rt.LastSeenUTC != nil
@@ -219,6 +534,7 @@ func (tp *TagProcessor) processData(rt *llrp.TagReportData, info ReportInfo) (ev
 		// only update last read if it is newer
 		if lastRead > tag.LastRead {
 			tag.LastRead = lastRead
+			tag.frozenAt = 0 // the tag's been read, so its departure clock restarts
 		}
 	}
 
@@ -228,12 +544,23 @@ func (tp *TagProcessor) processData(rt *llrp.TagReportData, info ReportInfo) (ev
 	}
 
 	readLocation := NewLocation(info.DeviceName, uint16(*rt.AntennaID))
+	if _, ok := tp.unavailable[readLocation.String()]; ok {
+		tp.setAvailable(readLocation.String(), time.Now().UnixMilli())
+	}
 	statsAtReadLoc := tag.getStats(readLocation.String())
 
 	if rssi, hasRSSI := rt.ExtractRSSI(); hasRSSI {
 		statsAtReadLoc.updateRSSI(rssi)
 	}
 
+	if phase, hasPhase := rt.ExtractPhaseAngle(); hasPhase {
+		statsAtReadLoc.updatePhase(phase)
+	}
+
+	if doppler, hasDoppler := rt.ExtractDoppler(); hasDoppler {
+		statsAtReadLoc.updateDoppler(doppler)
+	}
+
 	if hasTimestamp {
 		statsAtReadLoc.updateLastRead(lastRead)
 	}
@@ -256,18 +583,8 @@ func (tp *TagProcessor) processData(rt *llrp.TagReportData, info ReportInfo) (ev
 			logReadTiming(tp, info, statsAtPrevLoc, tag)
 		}
 
-		locationMean := statsAtPrevLoc.rssiDbm.Mean()
-		incomingMean := statsAtReadLoc.rssiDbm.Mean()
-
-		offset := tp.config.profile.computeOffset(info.referenceTimestamp, statsAtPrevLoc.lastRead)
-		if tp.isDebugLogging() {
-			logTagStats(tp, tag, readLocation.String(), incomingMean, locationMean, offset)
-		}
-
-		// Update the location if the mean RSSI at the new location
-		// is greater than the adjusted mean RSSI of the existing location.
 		// Note: This will generate a moved event.
-		if incomingMean > (locationMean + offset) {
+		if tp.outweighs(tag, readLocation, statsAtReadLoc.rssiDbm.Mean(), statsAtPrevLoc, info) {
 			tag.Location = readLocation
 		}
 	}
@@ -275,6 +592,140 @@ func (tp *TagProcessor) processData(rt *llrp.TagReportData, info ReportInfo) (ev
 	return
 }
 
+// outweighs returns true if a tag's mean RSSI at the read location
+// is greater than the mean RSSI at its current location,
+// adjusted by the mobility profile for how long ago it was last read there.
+func (tp *TagProcessor) outweighs(tag *Tag, readLocation Location, incomingMean float64, statsAtPrevLoc *tagStats, info ReportInfo) bool {
+	locationMean := statsAtPrevLoc.rssiDbm.Mean()
+	offset := tp.config.profile.computeOffset(info.referenceTimestamp, statsAtPrevLoc.lastRead)
+	if tp.isDebugLogging() {
+		logTagStats(tp, tag, readLocation.String(), incomingMean, locationMean, offset)
+	}
+	return incomingMean > (locationMean + offset)
+}
+
+// movedEvent returns a MovedEvent if the Present tag's location changed from prevLoc,
+// unless the two locations share an alias.
+func (tp *TagProcessor) movedEvent(tag *Tag, prevLoc Location) (Event, bool) {
+	if prevLoc.IsEmpty() || prevLoc.Equals(tag.Location) {
+		return nil, false
+	}
+
+	prevAlias := tp.getAlias(prevLoc.String())
+	curAlias := tp.getAlias(tag.Location.String())
+	if prevAlias == curAlias {
+		return nil, false // do not send event if the two locations share the same alias
+	}
+	return MovedEvent{
+		BaseEvent:   tag.baseEvent(tag.LastRead),
+		OldLocation: prevAlias,
+		NewLocation: curAlias,
+	}, true
+}
+
+// processTagInformation processes a gateway's location or direction report for a tag,
+// updating its Position or Direction.
+//
+// Reports count as reads of the tag by the gateway as a whole,
+// so a tag that isn't Present arrives at the gateway's antenna 0.
+// A tag Present at another reader moves to the gateway like it would for other reads,
+// but since the reports have no RSSI, the gateway's is taken to equal that at the tag's location,
+// so the tag moves once its stats there were cleared or the mobility profile favors the newer read.
+// Exit reports are ignored for tags that aren't Present, and don't move tags.
+func (tp *TagProcessor) processTagInformation(ti *llrp.ImpinjTagInformation, info ReportInfo) (events []Event) {
+	toMillis := func(micros uint64) int64 {
+		return (int64(micros) + info.offsetMicros) / 1000 // #nosec G115
+	}
+
+	epc := hex.EncodeToString(ti.EPC)
+	tag, exists := tp.inventory[epc]
+	exiting := (ti.Location == nil || ti.Location.Type == llrp.ImpinjExitReport) &&
+		(ti.Direction == nil || ti.Direction.Type == llrp.ImpinjExitReport)
+	if exiting && (!exists || tag.state != Present) {
+		return nil
+	}
+
+	if !exists {
+		tag = NewTag(epc)
+		tp.inventory[epc] = tag
+	}
+
+	lastRead := toMillis(ti.LastSeenUTC())
+	if lastRead > tag.LastRead {
+		tag.LastRead = lastRead
+		tag.frozenAt = 0 // the tag's been read, so its departure clock restarts
+	}
+
+	readLocation := NewLocation(info.DeviceName, 0)
+	statsAtReadLoc := tag.getStats(readLocation.String())
+	statsAtReadLoc.updateLastRead(lastRead)
+
+	switch {
+	case tag.state != Present:
+		tag.Location = readLocation
+		tag.setState(Present)
+		// the history only covers the tag's current visit
+		tag.LocationHistory = nil
+		tag.addLocationHistory(tp.config.locationHistorySize)
+		events = append(events, ArrivedEvent{
+			BaseEvent: tag.baseEvent(tag.LastRead),
+			Location:  tp.getAlias(tag.Location.String()),
+		})
+
+	case !exiting && tag.Location.DeviceName != info.DeviceName:
+		prevLoc := tag.Location
+		statsAtPrevLoc := tag.getStats(prevLoc.String())
+		if statsAtPrevLoc.rssiCount() == 0 ||
+			tp.outweighs(tag, readLocation, statsAtPrevLoc.rssiDbm.Mean(), statsAtPrevLoc, info) {
+			tag.Location = readLocation
+			tag.addLocationHistory(tp.config.locationHistorySize)
+			if moved, ok := tp.movedEvent(tag, prevLoc); ok {
+				events = append(events, moved)
+			}
+		}
+	}
+
+	if loc := ti.Location; loc != nil {
+		prev := tag.Position
+		tag.Position = &Position{
+			DeviceName: info.DeviceName,
+			X:          loc.X,
+			Y:          loc.Y,
+			Timestamp:  toMillis(loc.LastSeenUTC),
+		}
+
+		if prev == nil || prev.DeviceName != info.DeviceName || prev.X != loc.X || prev.Y != loc.Y {
+			events = append(events, PositionUpdatedEvent{
+				BaseEvent: tag.baseEvent(tag.Position.Timestamp),
+				Device:    info.DeviceName,
+				X:         loc.X,
+				Y:         loc.Y,
+			})
+		}
+	}
+
+	if dir := ti.Direction; dir != nil {
+		tag.Direction = &Direction{
+			DeviceName: info.DeviceName,
+			FromSector: dir.FirstSeenSector,
+			ToSector:   dir.LastSeenSector,
+			Timestamp:  toMillis(dir.LastSeenUTC),
+		}
+
+		// only the exit report is certain of where the tag ended up
+		if dir.Type == llrp.ImpinjExitReport && dir.FirstSeenSector != dir.LastSeenSector {
+			events = append(events, DirectionOfTravelEvent{
+				BaseEvent:  tag.baseEvent(tag.Direction.Timestamp),
+				Device:     info.DeviceName,
+				FromSector: dir.FirstSeenSector,
+				ToSector:   dir.LastSeenSector,
+			})
+		}
+	}
+
+	return events
+}
+
 func logTagStats(tp *TagProcessor, tag *Tag, readLocation string, incomingMean float64, existingMean float64, offset float64) {
 	tp.lc.Debug("tag stats",
 		"epc", tag.EPC,
@@ -334,24 +785,49 @@ func (tp *TagProcessor) AggregateDeparted() (events []Event, snapshot []StaticTa
 	// anything older than that is considered departed.
 	// #nosec G115
 	minTimestamp := now.Add(-1*time.Duration(tp.config.departedThresholdSeconds)*time.Second).UnixNano() / 1e6
+	// tags at exit locations only need to go unread for the exitTimeoutSeconds.
+	// #nosec G115
+	minExitTimestamp := now.Add(-1*time.Duration(tp.config.exitTimeoutSeconds)*time.Second).UnixNano() / 1e6
 
 	for _, tag := range tp.inventory {
-		if tag.state == Present && tag.LastRead < minTimestamp {
+		// tags restored with frozen clocks stay frozen until reading starts
+		if tag.state != Present || tag.frozenAt != 0 || tp.isUnavailable(tag.Location) {
+			continue
+		}
+
+		lastRead := max(tag.LastRead, tag.departureRef)
+		viaExit := lastRead < minExitTimestamp && tp.isExit(tag.Location)
+		if viaExit || lastRead < minTimestamp {
 			tag.setStateAt(Departed, nowMs)
 			e := DepartedEvent{
-				BaseEvent: BaseEvent{
-					EPC:       tag.EPC,
-					TID:       tag.TID,
-					Timestamp: nowMs,
-				},
+				BaseEvent:         tag.baseEvent(nowMs),
 				LastRead:          tag.LastRead,
 				LastKnownLocation: tp.getAlias(tag.Location.String()),
+				ViaExit:           viaExit,
 			}
 
 			// reset the read stats so if it arrives again it will start with fresh data
 			tag.resetStats()
-			tp.lc.Debug("Tag departed.", "epc", tag.EPC, "msSinceLastSeen", nowMs-tag.LastRead)
+			tp.lc.Debug("Tag departed.", "epc", tag.EPC, "msSinceLastSeen", nowMs-tag.LastRead, "viaExit", viaExit)
 			events = append(events, e)
+
+			// Tags restored from a snapshot that predates location histories have none,
+			// so there's no way to know whether they passed an exit or point of sale.
+			if tp.tracksExplanations() && !viaExit && len(tag.LocationHistory) != 0 && !tp.isExplainedDeparture(tag) {
+				path := make([]string, len(tag.LocationHistory))
+				for i, location := range tag.LocationHistory {
+					path[i] = tp.getAlias(location)
+				}
+
+				tp.lc.Info("Tag departed without passing an exit or point of sale.",
+					"epc", tag.EPC, "lastKnownLocation", e.LastKnownLocation)
+				events = append(events, UnexplainedDepartureEvent{
+					BaseEvent:         tag.baseEvent(nowMs),
+					LastRead:          tag.LastRead,
+					LastKnownLocation: e.LastKnownLocation,
+					Path:              path,
+				})
+			}
 		}
 	}
 
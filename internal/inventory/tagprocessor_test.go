@@ -7,11 +7,15 @@ package inventory
 
 import (
 	"fmt"
+	"math"
 	"testing"
 	"time"
 
+	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/logger"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -557,3 +561,521 @@ func TestEventLocationMatchesAlias(t *testing.T) {
 
 	}
 }
+
+func TestAliasUpdateEvents(t *testing.T) {
+	tests := []struct {
+		aliasChangeEvent string
+		expected         []EventType
+	}{
+		{aliasChangeEvent: string(LocationRenamedType), expected: []EventType{LocationRenamedType}},
+		{aliasChangeEvent: string(MovedType), expected: []EventType{MovedType}},
+		{aliasChangeEvent: aliasChangeNone, expected: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.aliasChangeEvent, func(t *testing.T) {
+			cfg := NewServiceConfig()
+			cfg.AppCustom.AppSettings.AliasChangeEvent = test.aliasChangeEvent
+			ds := newTestDataset(cfg, 10)
+			sensor1 := nextSensor()
+			sensor2 := nextSensor()
+
+			_ = ds.readAll(t, readParams{
+				deviceName: sensor1,
+				antenna:    defaultAntenna,
+			})
+			// a departed tag at the renamed location must not generate an event
+			departed := nextEPC()
+			_ = ds.readTag(t, departed, readParams{
+				deviceName: sensor1,
+				antenna:    defaultAntenna,
+				lastSeen:   time.Now().Add(-2 * time.Duration(ds.tp.config.departedThresholdSeconds) * time.Second), // #nosec G115
+			})
+			_, _ = ds.tp.AggregateDeparted()
+
+			// aliasing the inventory's location renames it for the present tags,
+			// but not the departed one; aliasing an unused location has no effect
+			newConfig := cfg.AppCustom
+			newConfig.Aliases = map[string]string{
+				NewLocation(sensor1, defaultAntenna).String(): "Freezer",
+				NewLocation(sensor2, defaultAntenna).String(): "BackRoom",
+			}
+			events, snapshot := ds.tp.UpdateConfig(newConfig)
+			require.NotNil(t, snapshot)
+			if test.expected == nil {
+				if err := ds.verifyNoEvents(events); err != nil {
+					t.Error(err)
+				}
+			} else if err := ds.verifyEventPattern(events, ds.size(), test.expected...); err != nil {
+				t.Error(err)
+			}
+			if err := ds.verifyAll(Present, "Freezer"); err != nil {
+				t.Error(err)
+			}
+
+			for _, e := range events {
+				var oldLoc, newLoc string
+				switch e := e.(type) {
+				case LocationRenamedEvent:
+					oldLoc, newLoc = e.OldLocation, e.NewLocation
+				case MovedEvent:
+					oldLoc, newLoc = e.OldLocation, e.NewLocation
+				}
+				assert.Equal(t, NewLocation(sensor1, defaultAntenna).String(), oldLoc)
+				assert.Equal(t, "Freezer", newLoc)
+			}
+
+			// the same aliases again should not generate any events or a new snapshot
+			events, snapshot = ds.tp.UpdateConfig(newConfig)
+			assert.Nil(t, snapshot)
+			if err := ds.verifyNoEvents(events); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestTIDConflict(t *testing.T) {
+	ds := newTestDataset(NewServiceConfig(), 1)
+	sensor := nextSensor()
+	epc := ds.epcs[0]
+
+	events := ds.readTag(t, epc, readParams{
+		deviceName: sensor,
+		antenna:    defaultAntenna,
+		readData:   []uint16{0xE280, 0x1160, 0x0001},
+		count:      2,
+	})
+	if err := ds.verifyEventPattern(events, 1, ArrivedType); err != nil {
+		t.Error(err)
+	}
+	tag := ds.tp.inventory[epc]
+	assert.False(t, tag.TIDConflict)
+	assert.Equal(t, []string{"e28011600001"}, tag.TIDs)
+
+	// a different physical tag with the same EPC
+	events = ds.readTag(t, epc, readParams{
+		deviceName: sensor,
+		antenna:    defaultAntenna,
+		readData:   []uint16{0xE280, 0x1160, 0x0002},
+	})
+	if err := ds.verifyEventPattern(events, 1, TIDConflictType); err != nil {
+		t.Fatal(err)
+	}
+	conflict := events[0].(TIDConflictEvent)
+	assert.Equal(t, "e28011600002", conflict.TID)
+	assert.Equal(t, []string{"e28011600001", "e28011600002"}, conflict.TIDs)
+	assert.Equal(t, ds.findAlias(sensor, defaultAntenna), conflict.Location)
+
+	// seeing either of the known TIDs again is not a new conflict
+	events = ds.readTag(t, epc, readParams{
+		deviceName: sensor,
+		antenna:    defaultAntenna,
+		readData:   []uint16{0xE280, 0x1160, 0x0001},
+	})
+	if err := ds.verifyNoEvents(events); err != nil {
+		t.Error(err)
+	}
+
+	// the conflict is marked in the snapshot and survives a restore
+	_, snapshot := ds.tp.ProcessReport(&llrp.ROAccessReport{}, ReportInfo{})
+	require.Len(t, snapshot, 1)
+	assert.True(t, snapshot[0].TIDConflict)
+	assert.Equal(t, conflict.TIDs, snapshot[0].TIDs)
+	// the snapshot doesn't share the tag's TIDs
+	ds.tp.inventory[epc].TIDs[0] = "e28011600003"
+	assert.Equal(t, conflict.TIDs, snapshot[0].TIDs)
+	restored := snapshot[0].asTagPtr()
+	assert.True(t, restored.TIDConflict)
+	assert.Equal(t, conflict.TIDs, restored.TIDs)
+	// nor does the restored tag share the snapshot's
+	restored.TIDs[0] = "e28011600003"
+	assert.Equal(t, conflict.TIDs, snapshot[0].TIDs)
+}
+
+func TestAddTID_limit(t *testing.T) {
+	tag := &Tag{}
+	for i := range maxTIDs + 2 {
+		tag.addTID(fmt.Sprintf("e2801160%04x", i))
+	}
+	require.Len(t, tag.TIDs, maxTIDs, "only the most recent TIDs are kept")
+	assert.Equal(t, "e28011600002", tag.TIDs[0])
+	assert.Equal(t, fmt.Sprintf("e2801160%04x", maxTIDs+1), tag.TIDs[maxTIDs-1])
+	assert.True(t, tag.TIDConflict)
+}
+
+func TestMemoryReads(t *testing.T) {
+	ds := newTestDataset(NewServiceConfig(), 1)
+	sensor := nextSensor()
+	epc := ds.epcs[0]
+	readNames := map[uint16]string{1: "tid", 2: "user"}
+
+	events := ds.readTag(t, epc, readParams{
+		deviceName:   sensor,
+		antenna:      defaultAntenna,
+		readData:     []uint16{0xE280, 0x1160, 0x0001},
+		readOpSpecID: 1,
+		readNames:    readNames,
+	})
+	if err := ds.verifyEventPattern(events, 1, ArrivedType); err != nil {
+		t.Fatal(err)
+	}
+	arrived := events[0].(ArrivedEvent)
+	assert.Equal(t, "e28011600001", arrived.TID)
+	assert.Equal(t, map[string]string{"tid": "e28011600001"}, arrived.Memory)
+
+	// user memory is recorded by name, but doesn't change the TID
+	events = ds.readTag(t, epc, readParams{
+		deviceName:   sensor,
+		antenna:      defaultAntenna,
+		readData:     []uint16{0xCAFE, 0xF00D},
+		readOpSpecID: 2,
+		readNames:    readNames,
+	})
+	if err := ds.verifyNoEvents(events); err != nil {
+		t.Error(err)
+	}
+	tag := ds.tp.inventory[epc]
+	assert.Equal(t, "e28011600001", tag.TID)
+	assert.Equal(t, map[string]string{"tid": "e28011600001", "user": "cafef00d"}, tag.Memory)
+
+	// results for unknown reads are ignored
+	ds.readTag(t, epc, readParams{
+		deviceName:   sensor,
+		antenna:      defaultAntenna,
+		readData:     []uint16{0x1234},
+		readOpSpecID: 3,
+		readNames:    readNames,
+	})
+	assert.Len(t, tag.Memory, 2)
+
+	// the memory is part of the snapshot, and survives a restore
+	_, snapshot := ds.tp.ProcessReport(&llrp.ROAccessReport{}, ReportInfo{})
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, tag.Memory, snapshot[0].Memory)
+	assert.Equal(t, tag.Memory, snapshot[0].asTagPtr().Memory)
+}
+
+func TestGatewayReports(t *testing.T) {
+	cfg := NewServiceConfig()
+	cfg.AppCustom.AppSettings.AdjustLastReadOnByOrigin = false
+	ds := newTestDataset(cfg, 0)
+	gateway := nextSensor()
+	info := ReportInfo{DeviceName: gateway}
+	epc, other := nextEPC(), nextEPC()
+	now := time.Now()
+
+	report := func(params ...llrp.Custom) []Event {
+		events, _ := ds.tp.ProcessReport(&llrp.ROAccessReport{Custom: params}, info)
+		return events
+	}
+
+	// A tag's first position report makes it arrive at the gateway.
+	events := report(gatewayTagInfo(t, epc, llrp.ImpinjLocationReportData,
+		locationReport(llrp.ImpinjEntryReport, now, 120, -40)))
+	require.Len(t, events, 2)
+	assert.Equal(t, ArrivedType, events[0].OfType())
+	assert.Equal(t, PositionUpdatedEvent{
+		BaseEvent: BaseEvent{EPC: epc, Timestamp: now.UnixMilli()},
+		Device:    gateway,
+		X:         120,
+		Y:         -40,
+	}, events[1])
+
+	tag := ds.tp.inventory[epc]
+	require.NotNil(t, tag)
+	assert.Equal(t, NewLocation(gateway, 0), tag.Location)
+	assert.Equal(t, now.UnixMilli(), tag.LastRead)
+
+	// Updates only generate events if the position changes.
+	later := now.Add(time.Second)
+	assert.Empty(t, report(gatewayTagInfo(t, epc, llrp.ImpinjLocationReportData,
+		locationReport(llrp.ImpinjUpdateReport, later, 120, -40))))
+	assert.Equal(t, later.UnixMilli(), tag.LastRead)
+
+	events = report(gatewayTagInfo(t, epc, llrp.ImpinjLocationReportData,
+		locationReport(llrp.ImpinjUpdateReport, later, 150, -40)))
+	require.Len(t, events, 1)
+	assert.Equal(t, PositionUpdatedType, events[0].OfType())
+
+	_, snapshot := ds.tp.ProcessReport(&llrp.ROAccessReport{}, info)
+	require.Len(t, snapshot, 1)
+	require.NotNil(t, snapshot[0].Position)
+	assert.Equal(t, int32(150), snapshot[0].Position.X)
+	assert.Equal(t, snapshot[0].Position, snapshot[0].asTagPtr().Position)
+
+	// Exit reports don't add tags to the inventory.
+	assert.Empty(t, report(gatewayTagInfo(t, other, llrp.ImpinjDirectionReportData,
+		directionReport(llrp.ImpinjExitReport, 2, 3, now, later))))
+	assert.NotContains(t, ds.tp.inventory, other)
+
+	// The direction of travel is only certain when the tag exits.
+	events = report(gatewayTagInfo(t, other, llrp.ImpinjDirectionReportData,
+		directionReport(llrp.ImpinjEntryReport, 2, 2, now, now)))
+	require.Len(t, events, 1)
+	assert.Equal(t, ArrivedType, events[0].OfType())
+
+	events = report(gatewayTagInfo(t, other, llrp.ImpinjDirectionReportData,
+		directionReport(llrp.ImpinjExitReport, 2, 3, now, later)))
+	require.Len(t, events, 1)
+	assert.Equal(t, DirectionOfTravelEvent{
+		BaseEvent:  BaseEvent{EPC: other, Timestamp: later.UnixMilli()},
+		Device:     gateway,
+		FromSector: 2,
+		ToSector:   3,
+	}, events[0])
+	assert.Equal(t, &Direction{DeviceName: gateway, FromSector: 2, ToSector: 3, Timestamp: later.UnixMilli()},
+		ds.tp.inventory[other].Direction)
+}
+
+func TestGatewayReports_move(t *testing.T) {
+	cfg := NewServiceConfig()
+	cfg.AppCustom.AppSettings.AdjustLastReadOnByOrigin = false
+	ds := newTestDataset(cfg, 0)
+	sensor, gateway := nextSensor(), nextSensor()
+	epc := nextEPC()
+	now := time.Now()
+
+	events := ds.readTag(t, epc, readParams{deviceName: sensor, antenna: defaultAntenna, lastSeen: now, count: 2})
+	if err := ds.verifyEventPattern(events, 1, ArrivedType); err != nil {
+		t.Fatal(err)
+	}
+	tag := ds.tp.inventory[epc]
+	require.NotNil(t, tag)
+
+	report := func(at time.Time, params ...llrp.Custom) []Event {
+		events, _ := ds.tp.ProcessReport(&llrp.ROAccessReport{Custom: params}, ReportInfo{
+			DeviceName:         gateway,
+			OriginNanos:        at.UnixNano(),
+			referenceTimestamp: at.UnixMilli(),
+		})
+		return events
+	}
+
+	// The reports have no RSSI, so while the tag's recent reads at the sensor
+	// outweigh the gateway's, the tag stays where it is.
+	events = report(now, gatewayTagInfo(t, epc, llrp.ImpinjLocationReportData,
+		locationReport(llrp.ImpinjEntryReport, now, 120, -40)))
+	if err := ds.verifyEventPattern(events, 1, PositionUpdatedType); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, NewLocation(sensor, defaultAntenna), tag.Location)
+
+	// Exit reports don't move the tag.
+	later := now.Add(5 * time.Second)
+	events = report(later, gatewayTagInfo(t, epc, llrp.ImpinjDirectionReportData,
+		directionReport(llrp.ImpinjExitReport, 2, 2, now, later)))
+	if err := ds.verifyNoEvents(events); err != nil {
+		t.Error(err)
+	}
+	assert.Equal(t, NewLocation(sensor, defaultAntenna), tag.Location)
+
+	// Once the mobility profile favors the newer read, the tag moves to the gateway.
+	events = report(later, gatewayTagInfo(t, epc, llrp.ImpinjLocationReportData,
+		locationReport(llrp.ImpinjUpdateReport, later, 150, -40)))
+	if err := ds.verifyEventPattern(events, 2, MovedType, PositionUpdatedType); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, MovedEvent{
+		BaseEvent:   BaseEvent{EPC: epc, Timestamp: later.UnixMilli()},
+		OldLocation: ds.findAlias(sensor, defaultAntenna),
+		NewLocation: ds.findAlias(gateway, 0),
+	}, events[0])
+	assert.Equal(t, NewLocation(gateway, 0), tag.Location)
+	assert.Equal(t, []string{NewLocation(sensor, defaultAntenna).String(), NewLocation(gateway, 0).String()},
+		tag.LocationHistory)
+
+	// The gateway's reports have no RSSI, so the sensor's next reads move the tag back.
+	events = ds.readTag(t, epc, readParams{deviceName: sensor, antenna: defaultAntenna, lastSeen: later})
+	if err := ds.verifyEventPattern(events, 1, MovedType); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, NewLocation(sensor, defaultAntenna), tag.Location)
+}
+
+func TestPhaseAndDoppler(t *testing.T) {
+	ds := newTestDataset(NewServiceConfig(), 1)
+	sensor := nextSensor()
+	epc := ds.epcs[0]
+	loc := NewLocation(sensor, defaultAntenna).String()
+
+	ds.readTag(t, epc, readParams{deviceName: sensor, antenna: defaultAntenna})
+	_, snapshot := ds.tp.ProcessReport(&llrp.ROAccessReport{}, ReportInfo{})
+	require.Len(t, snapshot, 1)
+	assert.Nil(t, snapshot[0].StatsMap[loc].PhaseAngle)
+	assert.Nil(t, snapshot[0].StatsMap[loc].MeanDoppler)
+
+	for _, doppler := range []int16{16, 48} {
+		ds.readTag(t, epc, readParams{
+			deviceName: sensor,
+			antenna:    defaultAntenna,
+			custom: []llrp.Custom{
+				{VendorID: uint32(llrp.PENImpinj), Subtype: llrp.ImpinjRFPhaseAngle, Data: []byte{0x04, 0x00}},
+				{VendorID: uint32(llrp.PENImpinj), Subtype: llrp.ImpinjRFDopplerFrequency,
+					Data: []byte{byte(uint16(doppler) >> 8), byte(doppler)}},
+			},
+		})
+	}
+
+	_, snapshot = ds.tp.ProcessReport(&llrp.ROAccessReport{}, ReportInfo{})
+	require.Len(t, snapshot, 1)
+	stats := snapshot[0].StatsMap[loc]
+	require.NotNil(t, stats.PhaseAngle)
+	assert.InDelta(t, math.Pi/2, *stats.PhaseAngle, 1e-9)
+	require.NotNil(t, stats.MeanDoppler)
+	assert.InDelta(t, 2.0, *stats.MeanDoppler, 1e-9)
+
+	restored := snapshot[0].asTagPtr().getStats(loc)
+	require.NotNil(t, restored.phaseRad)
+	assert.InDelta(t, math.Pi/2, *restored.phaseRad, 1e-9)
+	assert.InDelta(t, 2.0, restored.dopplerHz.Mean(), 1e-9)
+}
+
+func TestProcessAccessResults(t *testing.T) {
+	ds := newTestDataset(NewServiceConfig(), 2)
+	sensor := nextSensor()
+	killed, locked := ds.epcs[0], ds.epcs[1]
+	ds.readAll(t, readParams{deviceName: sensor, antenna: defaultAntenna})
+	location := ds.findAlias(sensor, defaultAntenna)
+
+	// failures and other operations don't change anything
+	events, snapshot := ds.tp.ProcessAccessResults(sensor, []llrp.AccessResult{
+		{EPC: killed, Operation: llrp.AccessOpKill, Result: "IncorrectPasswordError"},
+		{EPC: killed, Operation: llrp.AccessOpWrite, Success: true},
+	})
+	assert.Empty(t, events)
+	assert.Nil(t, snapshot)
+
+	locks := []llrp.TagLock{{Data: "User", Privilege: "ReadWrite"}}
+	events, snapshot = ds.tp.ProcessAccessResults(sensor, []llrp.AccessResult{
+		{EPC: killed, Operation: llrp.AccessOpKill, Success: true},
+		{EPC: locked, Operation: llrp.AccessOpLock, Success: true, Locks: locks},
+	})
+	if err := ds.verifyEventPattern(events, 2, TagKilledType, TagLockedType); err != nil {
+		t.Fatal(err)
+	}
+
+	killEvent := events[0].(TagKilledEvent)
+	assert.Equal(t, killed, killEvent.EPC)
+	assert.Equal(t, sensor, killEvent.Device)
+	assert.Equal(t, location, killEvent.LastKnownLocation)
+	assert.NotContains(t, ds.tp.inventory, killed)
+
+	lockEvent := events[1].(TagLockedEvent)
+	assert.Equal(t, locked, lockEvent.EPC)
+	assert.Equal(t, location, lockEvent.Location)
+	assert.Equal(t, map[string]string{"User": "ReadWrite"}, lockEvent.Locks)
+
+	require.Len(t, snapshot, 1)
+	assert.Equal(t, locked, snapshot[0].EPC)
+	assert.Equal(t, lockEvent.Locks, snapshot[0].Locks)
+	assert.Equal(t, lockEvent.Locks, snapshot[0].asTagPtr().Locks)
+}
+
+func TestExitDeparture(t *testing.T) {
+	sensor := nextSensor()
+	exitAntenna := uint16(2)
+	cfg := NewServiceConfig()
+	cfg.AppCustom.Aliases = map[string]string{NewLocation(sensor, exitAntenna).String(): "DockDoor"}
+	cfg.AppCustom.ExitLocations = []string{"DockDoor"}
+	cfg.AppCustom.AppSettings.ExitTimeoutSeconds = 5
+
+	ds := newTestDataset(cfg, 3)
+	atExit, recentlyAtExit, onShelf := ds.epcs[0], ds.epcs[1], ds.epcs[2]
+	past := time.Now().Add(-10 * time.Second)
+
+	ds.readTag(t, atExit, readParams{deviceName: sensor, antenna: exitAntenna, lastSeen: past})
+	ds.readTag(t, recentlyAtExit, readParams{deviceName: sensor, antenna: exitAntenna})
+	ds.readTag(t, onShelf, readParams{deviceName: sensor, antenna: defaultAntenna, lastSeen: past})
+
+	events, _ := ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+	departed := events[0].(DepartedEvent)
+	assert.Equal(t, atExit, departed.EPC)
+	assert.Equal(t, "DockDoor", departed.LastKnownLocation)
+	assert.True(t, departed.ViaExit)
+
+	assert.Equal(t, Departed, ds.tp.inventory[atExit].state)
+	assert.Equal(t, Present, ds.tp.inventory[recentlyAtExit].state)
+	assert.Equal(t, Present, ds.tp.inventory[onShelf].state)
+
+	// exits may also be given by their default location name
+	cfg.AppCustom.ExitLocations = []string{NewLocation(sensor, defaultAntenna).String()}
+	ds.tp.UpdateConfig(cfg.AppCustom)
+	events, _ = ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, onShelf, events[0].(DepartedEvent).EPC)
+	assert.True(t, events[0].(DepartedEvent).ViaExit)
+}
+
+func TestUnexplainedDeparture(t *testing.T) {
+	shelf := nextSensor()
+	register := nextSensor()
+	cfg := NewServiceConfig()
+	cfg.AppCustom.Aliases = map[string]string{NewLocation(register, defaultAntenna).String(): "Checkout"}
+	cfg.AppCustom.POSLocations = []string{"Checkout"}
+	cfg.AppCustom.AppSettings.DepartedThresholdSeconds = 5
+
+	ds := newTestDataset(cfg, 2)
+	sold, stolen := ds.epcs[0], ds.epcs[1]
+	past := time.Now().Add(-10 * time.Second)
+
+	ds.readTag(t, sold, readParams{deviceName: shelf, antenna: defaultAntenna, rssi: rssiMin, lastSeen: past})
+	ds.readTag(t, sold, readParams{deviceName: register, antenna: defaultAntenna, rssi: rssiStrong, count: 4, lastSeen: past})
+	ds.readTag(t, stolen, readParams{deviceName: shelf, antenna: defaultAntenna, rssi: rssiMin, lastSeen: past})
+
+	shelfAlias := ds.findAlias(shelf, defaultAntenna)
+	assert.Equal(t, []string{NewLocation(shelf, defaultAntenna).String(), NewLocation(register, defaultAntenna).String()},
+		ds.tp.inventory[sold].LocationHistory)
+	assert.Equal(t, []string{NewLocation(shelf, defaultAntenna).String()}, ds.tp.inventory[stolen].LocationHistory)
+
+	events, snapshot := ds.tp.AggregateDeparted()
+	require.Len(t, events, 3)
+	require.Len(t, snapshot, 2)
+
+	var unexplained []UnexplainedDepartureEvent
+	for _, e := range events {
+		if u, ok := e.(UnexplainedDepartureEvent); ok {
+			unexplained = append(unexplained, u)
+		}
+	}
+	require.Len(t, unexplained, 1)
+	assert.Equal(t, stolen, unexplained[0].EPC)
+	assert.Equal(t, shelfAlias, unexplained[0].LastKnownLocation)
+	assert.Equal(t, []string{shelfAlias}, unexplained[0].Path)
+
+	// the history restarts when the tag arrives again
+	ds.readTag(t, sold, readParams{deviceName: shelf, antenna: defaultAntenna})
+	assert.Equal(t, []string{NewLocation(shelf, defaultAntenna).String()}, ds.tp.inventory[sold].LocationHistory)
+
+	// tags restored without a location history aren't classified
+	restored := StaticTag{EPC: nextEPC(), Location: NewLocation(shelf, defaultAntenna),
+		LastRead: past.UnixMilli(), State: Present}
+	ds.tp.inventory[restored.EPC] = restored.asTagPtr()
+	events, _ = ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+
+	// without exit or point of sale locations, departures aren't classified
+	cfg.AppCustom.POSLocations = nil
+	ds.tp.UpdateConfig(cfg.AppCustom)
+	ds.readTag(t, stolen, readParams{deviceName: shelf, antenna: defaultAntenna, lastSeen: past})
+	events, _ = ds.tp.AggregateDeparted()
+	if err := ds.verifyEventPattern(events, 1, DepartedType); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestAddLocationHistory(t *testing.T) {
+	tag := NewTag("epc")
+	for _, ant := range []uint16{1, 1, 2, 3, 2, 4} {
+		tag.Location = NewLocation("dev", ant)
+		tag.addLocationHistory(3)
+	}
+	assert.Equal(t, []string{"dev_3", "dev_2", "dev_4"}, tag.LocationHistory)
+}
@@ -9,16 +9,21 @@ const (
 	tagStatsWindowSize = 20
 )
 
-// tagStats helps keep track of tag read rssi values over time
+// tagStats helps keep track of tag read rssi values over time,
+// along with the RF phase angle and Doppler frequency, if readers report them.
 type tagStats struct {
-	lastRead int64
-	rssiDbm  *circularBuffer
+	lastRead  int64
+	rssiDbm   *circularBuffer
+	dopplerHz *circularBuffer
+	// phaseRad is the most recently reported phase angle, if there is one.
+	phaseRad *float64
 }
 
 // newTagStats returns a new tagStats pointer with circular buffers initialized to the configured default window size
 func newTagStats() *tagStats {
 	return &tagStats{
-		rssiDbm: newCircularBuffer(tagStatsWindowSize),
+		rssiDbm:   newCircularBuffer(tagStatsWindowSize),
+		dopplerHz: newCircularBuffer(tagStatsWindowSize),
 	}
 }
 
@@ -26,6 +31,14 @@ func (stats *tagStats) updateRSSI(rssi float64) {
 	stats.rssiDbm.AddValue(rssi)
 }
 
+func (stats *tagStats) updateDoppler(doppler float64) {
+	stats.dopplerHz.AddValue(doppler)
+}
+
+func (stats *tagStats) updatePhase(phase float64) {
+	stats.phaseRad = &phase
+}
+
 func (stats *tagStats) updateLastRead(lastRead int64) {
 	// skip times that are at or before the current last read timestamp
 	if lastRead <= stats.lastRead {
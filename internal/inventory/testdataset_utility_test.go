@@ -7,6 +7,7 @@ package inventory
 
 import (
 	"edgexfoundry/app-rfid-llrp-inventory/internal/llrp"
+	"encoding/binary"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -65,6 +66,13 @@ type readParams struct {
 	lastSeen   time.Time
 	count      int
 	origin     time.Time
+	// readData, if set, is reported as the result of the C1G2 Read with readOpSpecID;
+	// without readNames, it's treated as the tag's TID
+	readData     []uint16
+	readOpSpecID uint16
+	readNames    map[uint16]string
+	// custom, if set, are reported as the tag's Custom parameters
+	custom []llrp.Custom
 }
 
 // sanitize modifies the readParams receiver to set default values if they were not
@@ -101,6 +109,11 @@ func (ds *testDataset) readTag(t *testing.T, epc string, params readParams) (eve
 	epcBytes, err := hex.DecodeString(epc)
 	require.NoError(t, err)
 
+	var readResult *llrp.C1G2ReadOpSpecResult
+	if params.readData != nil {
+		readResult = &llrp.C1G2ReadOpSpecResult{OpSpecID: params.readOpSpecID, Data: params.readData}
+	}
+
 	for i := 0; i < params.count; i++ {
 		r := &llrp.ROAccessReport{
 			TagReportData: []llrp.TagReportData{
@@ -108,9 +121,11 @@ func (ds *testDataset) readTag(t *testing.T, epc string, params readParams) (eve
 					EPC96: llrp.EPC96{
 						EPC: epcBytes,
 					},
-					PeakRSSI:    &rss,
-					LastSeenUTC: &seen,
-					AntennaID:   &ant,
+					PeakRSSI:             &rss,
+					LastSeenUTC:          &seen,
+					AntennaID:            &ant,
+					C1G2ReadOpSpecResult: readResult,
+					Custom:               params.custom,
 				},
 			},
 		}
@@ -120,6 +135,7 @@ func (ds *testDataset) readTag(t *testing.T, epc string, params readParams) (eve
 			OriginNanos:        params.origin.UnixNano(),
 			offsetMicros:       0,
 			referenceTimestamp: params.origin.UnixNano() / 1e6,
+			ReadNames:          params.readNames,
 		})
 		events = append(events, e...)
 	}
@@ -242,3 +258,45 @@ func (ds *testDataset) verifyInventoryCount(count int) error {
 	}
 	return nil
 }
+
+// gatewayTagInfo returns an ImpinjExtendedTagInformation parameter
+// holding the tag's EPC and a report with the given subtype and fields,
+// like those an Impinj gateway sends in Location or Direction mode.
+func gatewayTagInfo(t *testing.T, epc string, subtype llrp.ImpinjParamSubtype, fields []byte) llrp.Custom {
+	t.Helper()
+	epcBytes, err := hex.DecodeString(epc)
+	require.NoError(t, err)
+
+	data := binary.BigEndian.AppendUint16(nil, 241) // EPCData
+	data = binary.BigEndian.AppendUint16(data, uint16(6+len(epcBytes)))
+	data = binary.BigEndian.AppendUint16(data, uint16(8*len(epcBytes)))
+	data = append(data, epcBytes...)
+
+	data = binary.BigEndian.AppendUint16(data, 1023) // Custom
+	data = binary.BigEndian.AppendUint16(data, uint16(12+len(fields)))
+	data = binary.BigEndian.AppendUint32(data, uint32(llrp.PENImpinj))
+	data = binary.BigEndian.AppendUint32(data, subtype)
+	data = append(data, fields...)
+
+	return llrp.Custom{
+		VendorID: uint32(llrp.PENImpinj),
+		Subtype:  llrp.ImpinjExtendedTagInformation,
+		Data:     data,
+	}
+}
+
+// locationReport returns the fields of an ImpinjLocationReportData parameter.
+func locationReport(typ llrp.ImpinjReportType, lastSeen time.Time, x, y int32) []byte {
+	fields := binary.BigEndian.AppendUint64(nil, uint64(lastSeen.UnixMicro()))
+	fields = binary.BigEndian.AppendUint32(fields, uint32(x))
+	fields = binary.BigEndian.AppendUint32(fields, uint32(y))
+	return append(fields, uint8(typ))
+}
+
+// directionReport returns the fields of an ImpinjDirectionReportData parameter.
+func directionReport(typ llrp.ImpinjReportType, from, to uint8, firstSeen, lastSeen time.Time) []byte {
+	fields := []byte{uint8(typ), 0, from}
+	fields = binary.BigEndian.AppendUint64(fields, uint64(firstSeen.UnixMicro()))
+	fields = append(fields, to)
+	return binary.BigEndian.AppendUint64(fields, uint64(lastSeen.UnixMicro()))
+}
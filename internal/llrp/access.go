@@ -0,0 +1,359 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llrp
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// epcBitOffset is the bit address of the EPC within a C1G2 tag's EPC memory bank;
+// it follows the 16-bit StoredCRC and 16-bit StoredPC words.
+const epcBitOffset = 0x20
+
+// TagTarget selects the tags an AccessSpec operates upon
+// by matching either their EPC or their TID.
+//
+// Both values are hex-encoded. Exactly one of them must be set.
+type TagTarget struct {
+	EPC string `json:"epc,omitempty"`
+	TID string `json:"tid,omitempty"`
+}
+
+// TagSpec returns a C1G2TagSpec matching tags with the target's EPC or TID.
+func (t TagTarget) TagSpec() (C1G2TagSpec, error) {
+	if (t.EPC == "") == (t.TID == "") {
+		return C1G2TagSpec{}, fmt.Errorf("tag target must have exactly one of an EPC or TID")
+	}
+
+	bank, offset, value := MemoryBankEPC, uint16(epcBitOffset), t.EPC
+	if t.TID != "" {
+		bank, offset, value = MemoryBankTID, 0, t.TID
+	}
+
+	data, err := hex.DecodeString(value)
+	if err != nil {
+		return C1G2TagSpec{}, fmt.Errorf("invalid tag target %q: %w", value, err)
+	}
+
+	if len(data) == 0 || len(data) > 0xFFFF/8 {
+		return C1G2TagSpec{}, fmt.Errorf("invalid tag target length: %d bytes", len(data))
+	}
+
+	mask := make([]byte, len(data))
+	for i := range mask {
+		mask[i] = 0xFF
+	}
+
+	nBits := uint16(len(data) * 8) // #nosec G115 -- bounded above
+	return C1G2TagSpec{TagPattern1: C1G2TargetTag{
+		C1G2MemoryBank:     bank,
+		MatchFlag:          true,
+		MostSignificantBit: offset,
+		TagMaskNumBits:     nBits,
+		TagMask:            mask,
+		TagDataNumBits:     nBits,
+		TagData:            data,
+	}}, nil
+}
+
+// TagWrite describes data to write to a region of tag memory.
+type TagWrite struct {
+	MemoryBank  C1G2MemoryBankType `json:"memoryBank"`
+	WordAddress uint16             `json:"wordAddress"`
+	// Data is hex-encoded and must be a whole number of 16-bit words.
+	Data           string `json:"data"`
+	AccessPassword uint32 `json:"accessPassword,omitempty"`
+	// BlockWrite uses a C1G2BlockWrite instead of a C1G2Write,
+	// which some tags support to write multiple words at once.
+	BlockWrite bool `json:"blockWrite,omitempty"`
+}
+
+// newTargetAccessSpec returns an AccessSpec for the default ROSpec
+// using the given id as its AccessSpecID and targeting the given tags.
+//
+// If opCount is non-zero, the Reader stops executing the AccessSpec
+// after it's been performed that many times;
+// otherwise it runs until it is disabled or deleted.
+// The caller must fill in the AccessCommand's operation.
+func newTargetAccessSpec(id uint16, target TagTarget, opCount uint16) (*AccessSpec, error) {
+	tagSpec, err := target.TagSpec()
+	if err != nil {
+		return nil, err
+	}
+
+	trigger := AccessSpecStopTrigger{Trigger: AccessSpecStopTriggerNone}
+	if opCount != 0 {
+		trigger = AccessSpecStopTrigger{
+			Trigger:             AccessSpecStopTriggerOperationCount,
+			OperationCountValue: opCount,
+		}
+	}
+
+	return &AccessSpec{
+		AccessSpecID:  uint32(id),
+		AntennaID:     0,
+		AirProtocolID: AirProtoEPCGlobalClass1Gen2,
+		ROSpecID:      defaultROSpecID,
+		Trigger:       trigger,
+		AccessCommand: AccessCommand{C1G2TagSpec: tagSpec},
+	}, nil
+}
+
+// NewWriteAccessSpec returns an AccessSpec that writes data to the target tags.
+//
+// The id is used as both the AccessSpecID and the OpSpecID,
+// so results can be correlated to the AccessSpec that produced them.
+func NewWriteAccessSpec(id uint16, target TagTarget, w TagWrite, opCount uint16) (*AccessSpec, error) {
+	if w.MemoryBank > MemoryBankUser {
+		return nil, fmt.Errorf("invalid memory bank (%d not in [0, %d])", w.MemoryBank, MemoryBankUser)
+	}
+
+	data, err := HexToWords(w.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid write data: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("missing write data")
+	}
+
+	spec, err := newTargetAccessSpec(id, target, opCount)
+	if err != nil {
+		return nil, err
+	}
+
+	if w.BlockWrite {
+		spec.AccessCommand.C1G2BlockWrite = &C1G2BlockWrite{
+			OpSpecID:       id,
+			AccessPassword: w.AccessPassword,
+			C1G2MemoryBank: w.MemoryBank,
+			WordAddress:    w.WordAddress,
+			Data:           data,
+		}
+	} else {
+		spec.AccessCommand.C1G2Write = &C1G2Write{
+			OpSpecID:       id,
+			AccessPassword: w.AccessPassword,
+			C1G2MemoryBank: w.MemoryBank,
+			WordAddress:    w.WordAddress,
+			Data:           data,
+		}
+	}
+
+	return spec, nil
+}
+
+// TagLock describes a change to the lock state of one region of tag memory.
+//
+// Data names the region: KillPassword, AccessPassword, EPC, TID, or User.
+// Privilege names the change: ReadWrite, Permalock, Permaunlock, or Unlock.
+type TagLock struct {
+	Data      string `json:"data"`
+	Privilege string `json:"privilege"`
+}
+
+var (
+	lockDataTypes = map[string]LockDataType{
+		"KillPassword":   LockDataKillPwd,
+		"AccessPassword": LockDataAccessPwd,
+		"EPC":            LockDataEPCMemory,
+		"TID":            LockDataTIDMemory,
+		"User":           LockDataUserMemory,
+	}
+
+	lockPrivilegeTypes = map[string]LockPrivilegeType{
+		"ReadWrite":   LockPrivRW,
+		"Permalock":   LockPrivPermalock,
+		"Permaunlock": LockPrivPermaunlock,
+		"Unlock":      LockPrivUnlock,
+	}
+)
+
+// IsPermanent returns true if the lock can never be changed once applied.
+func (l TagLock) IsPermanent() bool {
+	p, ok := lockPrivilegeTypes[l.Privilege]
+	return ok && (p == LockPrivPermalock || p == LockPrivPermaunlock)
+}
+
+// payload returns the C1G2LockPayload for the TagLock.
+func (l TagLock) payload() (C1G2LockPayload, error) {
+	data, ok := lockDataTypes[l.Data]
+	if !ok {
+		return C1G2LockPayload{}, fmt.Errorf("unknown lock data %q", l.Data)
+	}
+
+	priv, ok := lockPrivilegeTypes[l.Privilege]
+	if !ok {
+		return C1G2LockPayload{}, fmt.Errorf("unknown lock privilege %q", l.Privilege)
+	}
+
+	return C1G2LockPayload{LockPrivilege: priv, LockData: data}, nil
+}
+
+// NewLockAccessSpec returns an AccessSpec that changes the lock state of the target tags.
+//
+// The id is used as both the AccessSpecID and the OpSpecID,
+// so results can be correlated to the AccessSpec that produced them.
+func NewLockAccessSpec(id uint16, target TagTarget, accessPassword uint32, locks []TagLock, opCount uint16) (*AccessSpec, error) {
+	if len(locks) == 0 {
+		return nil, fmt.Errorf("missing lock payloads")
+	}
+
+	payloads := make([]C1G2LockPayload, len(locks))
+	for i, l := range locks {
+		p, err := l.payload()
+		if err != nil {
+			return nil, err
+		}
+		payloads[i] = p
+	}
+
+	spec, err := newTargetAccessSpec(id, target, opCount)
+	if err != nil {
+		return nil, err
+	}
+
+	spec.AccessCommand.C1G2Lock = &C1G2Lock{
+		OpSpecID:         id,
+		AccessPassword:   accessPassword,
+		C1G2LockPayloads: payloads,
+	}
+	return spec, nil
+}
+
+// NewKillAccessSpec returns an AccessSpec that permanently disables the target tags.
+//
+// The id is used as both the AccessSpecID and the OpSpecID,
+// so results can be correlated to the AccessSpec that produced them.
+func NewKillAccessSpec(id uint16, target TagTarget, killPassword uint32, opCount uint16) (*AccessSpec, error) {
+	// C1G2 tags ignore kill commands when their kill password is zero.
+	if killPassword == 0 {
+		return nil, fmt.Errorf("kill password must not be zero")
+	}
+
+	spec, err := newTargetAccessSpec(id, target, opCount)
+	if err != nil {
+		return nil, err
+	}
+
+	spec.AccessCommand.C1G2Kill = &C1G2Kill{
+		OpSpecID:     id,
+		KillPassword: killPassword,
+	}
+	return spec, nil
+}
+
+// Operations reported in AccessResults.
+const (
+	AccessOpWrite      = "Write"
+	AccessOpBlockWrite = "BlockWrite"
+	AccessOpKill       = "Kill"
+	AccessOpLock       = "Lock"
+)
+
+// AccessResult is the outcome of an AccessSpec operation on a single tag.
+type AccessResult struct {
+	EPC          string `json:"epc"`
+	OpSpecID     uint16 `json:"opSpecId"`
+	Operation    string `json:"operation"`
+	Result       string `json:"result"`
+	Success      bool   `json:"success"`
+	WordsWritten uint16 `json:"wordsWritten,omitempty"`
+	// Locks are the lock changes requested by a Lock operation.
+	// Readers don't report them, so they must be filled in by the caller.
+	Locks []TagLock `json:"locks,omitempty"`
+}
+
+// writeResultStrs name the results shared by C1G2WriteOpSpecResults
+// and C1G2BlockWriteOpSpecResults.
+var writeResultStrs = [...]string{
+	"Success",
+	"TagMemoryOverrunError",
+	"TagMemoryLockedError",
+	"InsufficientPower",
+	"NonspecificTagError",
+	"NoResponseFromTag",
+	"NonspecificReaderError",
+	"IncorrectPasswordError",
+}
+
+var killResultStrs = [...]string{
+	"Success",
+	"ZeroKillPasswordError",
+	"InsufficientPower",
+	"NonspecificTagError",
+	"NoResponseFromTag",
+	"NonspecificReaderError",
+	"IncorrectPasswordError",
+}
+
+var lockResultStrs = [...]string{
+	"Success",
+	"InsufficientPower",
+	"NonspecificTagError",
+	"NoResponseFromTag",
+	"NonspecificReaderError",
+	"IncorrectPasswordError",
+	"TagMemoryOverrunError",
+	"TagMemoryLockedError",
+}
+
+func resultString(strs []string, result uint8) string {
+	if int(result) < len(strs) {
+		return strs[result]
+	}
+	return fmt.Sprintf("Unknown(%d)", result)
+}
+
+// AccessResults returns the results of any write, kill, or lock operations in the TagReportData.
+func (rt *TagReportData) AccessResults() []AccessResult {
+	var results []AccessResult
+
+	if res := rt.C1G2WriteOpSpecResult; res != nil {
+		results = append(results, AccessResult{
+			EPC:          rt.EPCAsHex(),
+			OpSpecID:     res.OpSpecID,
+			Operation:    AccessOpWrite,
+			Result:       resultString(writeResultStrs[:], uint8(res.C1G2WriteOpSpecResultType)),
+			Success:      res.C1G2WriteOpSpecResultType == 0,
+			WordsWritten: res.WordsWritten,
+		})
+	}
+
+	if res := rt.C1G2BlockWriteOpSpecResult; res != nil {
+		results = append(results, AccessResult{
+			EPC:          rt.EPCAsHex(),
+			OpSpecID:     res.OpSpecID,
+			Operation:    AccessOpBlockWrite,
+			Result:       resultString(writeResultStrs[:], uint8(res.C1G2BlockWriteResult)),
+			Success:      res.C1G2BlockWriteResult == 0,
+			WordsWritten: res.WordsWritten,
+		})
+	}
+
+	if res := rt.C1G2KillOpSpecResult; res != nil {
+		results = append(results, AccessResult{
+			EPC:       rt.EPCAsHex(),
+			OpSpecID:  res.OpSpecID,
+			Operation: AccessOpKill,
+			Result:    resultString(killResultStrs[:], uint8(res.C1G2KillResult)),
+			Success:   res.C1G2KillResult == 0,
+		})
+	}
+
+	if res := rt.C1G2LockOpSpecResult; res != nil {
+		results = append(results, AccessResult{
+			EPC:       rt.EPCAsHex(),
+			OpSpecID:  res.OpSpecID,
+			Operation: AccessOpLock,
+			Result:    resultString(lockResultStrs[:], uint8(res.C1G2LockResult)),
+			Success:   res.C1G2LockResult == 0,
+		})
+	}
+
+	return results
+}
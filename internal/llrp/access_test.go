@@ -0,0 +1,167 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llrp
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagTarget_TagSpec(t *testing.T) {
+	spec, err := TagTarget{EPC: "30143639f84191ad22900204"}.TagSpec()
+	require.NoError(t, err)
+	tt := spec.TagPattern1
+	assert.Equal(t, MemoryBankEPC, tt.C1G2MemoryBank)
+	assert.True(t, tt.MatchFlag)
+	assert.Equal(t, uint16(epcBitOffset), tt.MostSignificantBit)
+	assert.Equal(t, uint16(96), tt.TagMaskNumBits)
+	assert.Equal(t, uint16(96), tt.TagDataNumBits)
+	assert.Len(t, tt.TagMask, 12)
+	assert.Equal(t, []byte{0x30, 0x14, 0x36, 0x39, 0xf8, 0x41, 0x91, 0xad, 0x22, 0x90, 0x02, 0x04}, tt.TagData)
+	assert.Nil(t, spec.TagPattern2)
+
+	spec, err = TagTarget{TID: "e2801160"}.TagSpec()
+	require.NoError(t, err)
+	tt = spec.TagPattern1
+	assert.Equal(t, MemoryBankTID, tt.C1G2MemoryBank)
+	assert.Zero(t, tt.MostSignificantBit)
+	assert.Equal(t, uint16(32), tt.TagDataNumBits)
+
+	for _, target := range []TagTarget{
+		{},
+		{EPC: "3014", TID: "e280"},
+		{EPC: "not hex"},
+		{TID: "abc"},
+	} {
+		_, err := target.TagSpec()
+		assert.Error(t, err, "expected an error for target %+v", target)
+	}
+}
+
+func TestNewWriteAccessSpec(t *testing.T) {
+	target := TagTarget{EPC: "3014"}
+	w := TagWrite{MemoryBank: MemoryBankUser, WordAddress: 4, Data: "cafef00d", AccessPassword: 1}
+
+	spec, err := NewWriteAccessSpec(0x8001, target, w, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(0x8001), spec.AccessSpecID)
+	assert.Equal(t, uint32(defaultROSpecID), spec.ROSpecID)
+	assert.Equal(t, AccessSpecStopTriggerOperationCount, spec.Trigger.Trigger)
+	assert.Equal(t, uint16(1), spec.Trigger.OperationCountValue)
+	assert.Nil(t, spec.AccessCommand.C1G2BlockWrite)
+	require.NotNil(t, spec.AccessCommand.C1G2Write)
+	assert.Equal(t, C1G2Write{
+		OpSpecID:       0x8001,
+		AccessPassword: 1,
+		C1G2MemoryBank: MemoryBankUser,
+		WordAddress:    4,
+		Data:           []uint16{0xcafe, 0xf00d},
+	}, *spec.AccessCommand.C1G2Write)
+
+	w.BlockWrite = true
+	spec, err = NewWriteAccessSpec(0x8002, target, w, 0)
+	require.NoError(t, err)
+	assert.Equal(t, AccessSpecStopTriggerNone, spec.Trigger.Trigger)
+	assert.Nil(t, spec.AccessCommand.C1G2Write)
+	require.NotNil(t, spec.AccessCommand.C1G2BlockWrite)
+	assert.Equal(t, uint16(0x8002), spec.AccessCommand.C1G2BlockWrite.OpSpecID)
+
+	for _, w := range []TagWrite{
+		{MemoryBank: 4, Data: "cafe"},
+		{MemoryBank: MemoryBankUser},
+		{MemoryBank: MemoryBankUser, Data: "caf"},
+	} {
+		_, err := NewWriteAccessSpec(1, target, w, 1)
+		assert.Error(t, err, "expected an error for write %+v", w)
+	}
+
+	_, err = NewWriteAccessSpec(1, TagTarget{}, TagWrite{Data: "cafe"}, 1)
+	assert.Error(t, err)
+}
+
+func TestAccessResults(t *testing.T) {
+	assert.Empty(t, (&TagReportData{}).AccessResults())
+
+	rt := TagReportData{
+		EPC96: EPC96{EPC: []byte{0x30, 0x14}},
+		C1G2WriteOpSpecResult: &C1G2WriteOpSpecResult{
+			C1G2WriteOpSpecResultType: 0,
+			OpSpecID:                  0x8001,
+			WordsWritten:              2,
+		},
+		C1G2BlockWriteOpSpecResult: &C1G2BlockWriteOpSpecResult{
+			C1G2BlockWriteResult: 2,
+			OpSpecID:             0x8002,
+		},
+	}
+	assert.Equal(t, []AccessResult{
+		{EPC: "3014", OpSpecID: 0x8001, Operation: AccessOpWrite, Result: "Success", Success: true, WordsWritten: 2},
+		{EPC: "3014", OpSpecID: 0x8002, Operation: AccessOpBlockWrite, Result: "TagMemoryLockedError"},
+	}, rt.AccessResults())
+
+	rt = TagReportData{
+		EPCData:              EPCData{EPCNumBits: 16, EPC: []byte{0x30, 0x15}},
+		C1G2KillOpSpecResult: &C1G2KillOpSpecResult{C1G2KillResult: 1, OpSpecID: 0x8003},
+		C1G2LockOpSpecResult: &C1G2LockOpSpecResult{C1G2LockResult: 0, OpSpecID: 0x8004},
+	}
+	assert.Equal(t, []AccessResult{
+		{EPC: "3015", OpSpecID: 0x8003, Operation: AccessOpKill, Result: "ZeroKillPasswordError"},
+		{EPC: "3015", OpSpecID: 0x8004, Operation: AccessOpLock, Result: "Success", Success: true},
+	}, rt.AccessResults())
+
+	rt = TagReportData{C1G2WriteOpSpecResult: &C1G2WriteOpSpecResult{C1G2WriteOpSpecResultType: 100}}
+	assert.Equal(t, "Unknown(100)", rt.AccessResults()[0].Result)
+}
+
+func TestNewLockAccessSpec(t *testing.T) {
+	target := TagTarget{TID: "e280"}
+	locks := []TagLock{
+		{Data: "User", Privilege: "ReadWrite"},
+		{Data: "AccessPassword", Privilege: "Permalock"},
+	}
+
+	spec, err := NewLockAccessSpec(0x8001, target, 0x1234, locks, 1)
+	require.NoError(t, err)
+	require.NotNil(t, spec.AccessCommand.C1G2Lock)
+	assert.Equal(t, C1G2Lock{
+		OpSpecID:       0x8001,
+		AccessPassword: 0x1234,
+		C1G2LockPayloads: []C1G2LockPayload{
+			{LockPrivilege: LockPrivRW, LockData: LockDataUserMemory},
+			{LockPrivilege: LockPrivPermalock, LockData: LockDataAccessPwd},
+		},
+	}, *spec.AccessCommand.C1G2Lock)
+
+	assert.False(t, locks[0].IsPermanent())
+	assert.True(t, locks[1].IsPermanent())
+	assert.True(t, TagLock{Data: "EPC", Privilege: "Permaunlock"}.IsPermanent())
+
+	for _, locks := range [][]TagLock{
+		nil,
+		{{Data: "Reserved", Privilege: "ReadWrite"}},
+		{{Data: "User", Privilege: "Forever"}},
+	} {
+		_, err := NewLockAccessSpec(1, target, 0, locks, 1)
+		assert.Error(t, err, "expected an error for locks %+v", locks)
+	}
+}
+
+func TestNewKillAccessSpec(t *testing.T) {
+	target := TagTarget{EPC: "3014"}
+
+	spec, err := NewKillAccessSpec(0x8001, target, 0xDEADBEEF, 1)
+	require.NoError(t, err)
+	require.NotNil(t, spec.AccessCommand.C1G2Kill)
+	assert.Equal(t, C1G2Kill{OpSpecID: 0x8001, KillPassword: 0xDEADBEEF}, *spec.AccessCommand.C1G2Kill)
+
+	_, err = NewKillAccessSpec(0x8001, target, 0, 1)
+	assert.Error(t, err)
+
+	_, err = NewKillAccessSpec(0x8001, TagTarget{}, 0xDEADBEEF, 1)
+	assert.Error(t, err)
+}
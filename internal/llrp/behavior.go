@@ -9,9 +9,14 @@ package llrp
 
 import (
 	"bytes"
+	"encoding/binary"
+	"encoding/hex"
 	"fmt"
+	"math"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 )
 
 // Behavior is a high-level description of desired Reader operation.
@@ -19,21 +24,132 @@ import (
 // LLRP Readers vary wildly in their capabilities;
 // some Behavior characteristics cannot be well-mapped to all Readers.
 type Behavior struct {
-	GPITrigger    *GPITrigger    `json:"gpiTrigger,omitempty"`
-	ImpinjOptions *ImpinjOptions `json:"impinjOptions,omitempty"`
+	GPITrigger      *GPITrigger      `json:"gpiTrigger,omitempty"`
+	PeriodicTrigger *PeriodicTrigger `json:"periodicTrigger,omitempty"`
+	ImpinjOptions   *ImpinjOptions   `json:"impinjOptions,omitempty"`
 
 	ScanType    ScanType    `json:"scanType"`
 	Duration    Millisecs32 `json:"duration"` // 0 = repeat forever
 	Power       PowerTarget `json:"power"`
 	Frequencies []Kilohertz `json:"frequencies,omitempty"` // ignored in Hopping regions
+
+	// Antennas, if not empty, limits the Reader to the listed antennas,
+	// each with its own settings.
+	// Otherwise, the Reader uses all its antennas with the same settings.
+	Antennas []AntennaSettings `json:"antennas,omitempty"`
+
+	// Sequence, if not empty, has the Reader scan its antennas in order,
+	// one step at a time, repeating the sequence until the Behavior's Duration expires.
+	// Without a GPI or Periodic trigger, it must have a Duration;
+	// with one, and no Duration, the sequence runs once each time the trigger fires.
+	// Readers that can't hold a spec for every step
+	// instead scan all the sequence's antennas at once, in their own order.
+	Sequence []AntennaStep `json:"sequence,omitempty"`
+
+	// MemoryReads lists tag memory regions the Reader should read
+	// from each tag it singulates.
+	// Since Readers perform at most one AccessSpec per tag
+	// and report one read result per tag, there can only be one.
+	MemoryReads []MemoryRead `json:"memoryReads,omitempty"`
+
+	// Report, if set, changes what the Reader reports about each tag, and when.
+	Report *ReportSettings `json:"report,omitempty"`
+
+	// Filters, if not empty, limit which tags the Reader inventories.
+	// The Reader applies them in order, so a tag is inventoried
+	// if it matches all the Include filters and none of the Exclude filters.
+	Filters []TagFilter `json:"filters,omitempty"`
+}
+
+// ReportSettings select the optional data Readers include in tag reports,
+// and when they send them.
+// Reports always include each tag's antenna, peak RSSI, and last seen time.
+type ReportSettings struct {
+	// Trigger and N, if Trigger isn't zero, determine when the Reader sends reports;
+	// otherwise, it reports each tag as soon as it's seen.
+	Trigger ROReportTriggerType `json:"trigger,omitempty"`
+	N       uint16              `json:"n,omitempty"`
+
+	FirstSeen    bool `json:"firstSeen,omitempty"`
+	ChannelIndex bool `json:"channelIndex,omitempty"`
+	TagSeenCount bool `json:"tagSeenCount,omitempty"`
+	PCBits       bool `json:"pcBits,omitempty"`
+	CRC          bool `json:"crc,omitempty"`
+	ROSpecID     bool `json:"roSpecID,omitempty"`
+	SpecIndex    bool `json:"specIndex,omitempty"`
+}
+
+// TagFilter matches tags by a region of their memory,
+// which the Reader uses to Select the tags it inventories.
+type TagFilter struct {
+	MemoryBank C1G2MemoryBankType `json:"memoryBank"`
+	// BitPointer is the bit address at which the Mask starts;
+	// note that in the EPC bank, the EPC itself starts at bit 32.
+	BitPointer uint16 `json:"bitPointer"`
+	// Mask is the hex-encoded data that matching tags have at the BitPointer.
+	Mask string `json:"mask"`
+	// MaskBits, if not zero, limits the Mask to its first MaskBits bits.
+	MaskBits uint16 `json:"maskBits,omitempty"`
+
+	Action FilterAction `json:"action"`
+	// Truncate has tags matching the final filter in the EPC bank
+	// reply with only the part of their EPC after the Mask.
+	Truncate bool `json:"truncate,omitempty"`
+}
+
+// FilterAction determines what a TagFilter does with matching tags.
+type FilterAction int
+
+const (
+	// FilterInclude limits the inventory to matching tags.
+	FilterInclude = FilterAction(iota)
+	// FilterExclude removes matching tags from the inventory.
+	FilterExclude
+)
+
+// MemoryRead describes a region of tag memory the Reader should read
+// every time it singulates a tag.
+//
+// The Name is used to correlate the read's result with the region:
+// results are reported in the tag's memory map under this name.
+// The name "tid" has special meaning to the inventory:
+// its data is also used as the tag's TID.
+type MemoryRead struct {
+	Name           string             `json:"name"`
+	MemoryBank     C1G2MemoryBankType `json:"memoryBank"`
+	WordAddress    uint16             `json:"wordAddress"`
+	WordCount      uint16             `json:"wordCount"` // 0 = read to the end of the bank
+	AccessPassword uint32             `json:"accessPassword,omitempty"`
 }
 
+// C1G2 tag memory banks.
+const (
+	MemoryBankReserved = C1G2MemoryBankType(0)
+	MemoryBankEPC      = C1G2MemoryBankType(1)
+	MemoryBankTID      = C1G2MemoryBankType(2)
+	MemoryBankUser     = C1G2MemoryBankType(3)
+)
+
 type GPITrigger struct {
 	Port    uint16
 	Event   bool
 	Timeout Millisecs32 `json:"timeout,omitempty"`
 }
 
+// PeriodicTrigger has the Reader start its ROSpec on its own schedule,
+// running for the Behavior's Duration each time.
+//
+// The first run starts Offset after Start, or after the ROSpec is enabled if Start is nil.
+// If Period isn't zero, the ROSpec runs again every Period thereafter;
+// otherwise, it only runs once.
+type PeriodicTrigger struct {
+	Offset Millisecs32 `json:"offset,omitempty"`
+	Period Millisecs32 `json:"period,omitempty"`
+	// Start, if set, is the UTC time from which the Offset is measured.
+	// It requires a Reader with a UTC clock.
+	Start *time.Time `json:"start,omitempty"`
+}
+
 // ImpinjOptions control behaviors that will only apply to Impinj Readers,
 // usually because they make use of some custom behavior only implemented there.
 type ImpinjOptions struct {
@@ -48,6 +164,37 @@ type ImpinjOptions struct {
 	// and thus will get re-inventoried every so often,
 	// regardless of movement in and out of antennas' Fields of View.
 	SuppressMonza bool `json:"suppressMonza"`
+
+	// ReportPhaseAngle and ReportDoppler have the Reader include
+	// each tag's RF phase angle and Doppler frequency in its reports,
+	// which help tell moving tags from stationary ones.
+	ReportPhaseAngle bool `json:"reportPhaseAngle,omitempty"`
+	ReportDoppler    bool `json:"reportDoppler,omitempty"`
+
+	// OptimizedRead has the Reader perform the Behavior's MemoryReads
+	// as part of inventory, rather than with AccessSpecs.
+	// Like with AccessSpecs, the Reader performs at most one read this way.
+	OptimizedRead bool `json:"optimizedRead,omitempty"`
+
+	// Location and Direction run an xArray or xSpan gateway
+	// in its Location or Direction mode instead of inventorying tags,
+	// so that it reports tags' positions or directions of travel.
+	// At most one may be set, and only for gateways that support the mode.
+	Location  *ImpinjLocation  `json:"location,omitempty"`
+	Direction *ImpinjDirection `json:"direction,omitempty"`
+}
+
+// customReport returns true if the Behavior needs Readers to report tags
+// differently than they do by default.
+func (b Behavior) customReport() bool {
+	return b.Report != nil || (b.ImpinjOptions != nil &&
+		(b.ImpinjOptions.ReportPhaseAngle || b.ImpinjOptions.ReportDoppler || b.ImpinjOptions.Location != nil))
+}
+
+// optimizedRead returns true if the Behavior has MemoryReads
+// that Impinj Readers should perform during inventory.
+func (b Behavior) optimizedRead() bool {
+	return len(b.MemoryReads) != 0 && b.ImpinjOptions != nil && b.ImpinjOptions.OptimizedRead
 }
 
 // PowerTarget specifies a target power for the Reader to push through the antenna.
@@ -60,6 +207,49 @@ type PowerTarget struct {
 	Max MillibelMilliwatt `json:"max"`
 }
 
+// AntennaSettings configures a single antenna.
+// Settings that aren't set use the Behavior's values or the Reader's defaults.
+type AntennaSettings struct {
+	ID AntennaID `json:"id"`
+	// Power, if set, overrides the Behavior's Power for this antenna.
+	Power *PowerTarget `json:"power,omitempty"`
+	// ReceiveSensitivity, if set, is the antenna's receive sensitivity
+	// in the units of the ReceiveSensitivities in the Reader's capabilities,
+	// which are dB relative its maximum sensitivity.
+	// The Reader uses the closest value it supports for the antenna.
+	ReceiveSensitivity *Decibel `json:"receiveSensitivity,omitempty"`
+}
+
+// AntennaStep is one step of a Behavior's antenna Sequence:
+// the antennas it scans and how long it dwells on them.
+// At least one of Dwell or QuietTime must be set.
+type AntennaStep struct {
+	Antennas []AntennaID `json:"antennas"`
+	// Dwell, if not zero, is the longest the step lasts.
+	Dwell Millisecs32 `json:"dwell,omitempty"`
+	// QuietTime, if not zero, ends the step once no new tags are observed for this long.
+	QuietTime Millisecs16 `json:"quietTime,omitempty"`
+}
+
+// stopTrigger returns the AISpecStopTrigger that ends the step.
+func (s AntennaStep) stopTrigger() AISpecStopTrigger {
+	if s.QuietTime == 0 {
+		return AISpecStopTrigger{
+			Trigger:              AIStopTriggerDuration,
+			DurationTriggerValue: s.Dwell,
+		}
+	}
+
+	return AISpecStopTrigger{
+		Trigger: AIStopTriggerTagObservation,
+		TagObservationTrigger: &TagObservationTrigger{
+			Trigger: TagObsTriggerNoNewAfterT,
+			T:       s.QuietTime,
+			Timeout: s.Dwell,
+		},
+	}
+}
+
 type ScanType int
 
 const (
@@ -71,6 +261,8 @@ const (
 var (
 	ErrMissingCapInfo = fmt.Errorf("missing capability information")
 	ErrUnsatisfiable  = fmt.Errorf("behavior cannot be satisfied")
+	ErrInvalidGPO     = fmt.Errorf("invalid GPO write")
+	ErrInvalidGPI     = fmt.Errorf("invalid GPI port")
 )
 
 func errMissingCapInfo(name string, path ...string) error {
@@ -101,10 +293,19 @@ type BasicDevice struct {
 	// lastData is the value of tag parameter the last time it was reported.
 	lastData TagReportData
 
+	// sensitivities and sensitivityRanges are the Reader's receive sensitivities
+	// and the ranges of them that are valid for particular antennas.
+	sensitivities     []ReceiveSensitivityTableEntry
+	sensitivityRanges []PerAntennaReceiveSensitivityRange
+
 	nGPIs, nFreqs uint16
+	nGPOs         uint16
+	nAntennas     uint16
 	nSpecsPerRO   uint32
+	nFilters      uint16 // 0 = no maximum
 	allowsHop     bool
 	stateAware    bool
+	hasUTCClock   bool
 }
 
 // ImpinjDevice embeds BasicDevice to provide some Impinj-specific Behavior implementations.
@@ -137,6 +338,7 @@ type BasicDevice struct {
 //     There is a custom parameter for "Search Mode" which essentially does it.
 type ImpinjDevice struct {
 	BasicDevice
+	model ImpinjModel
 }
 
 func NewBasicDevice(c *GetReaderCapabilitiesResponse) (*BasicDevice, error) {
@@ -233,10 +435,18 @@ func NewBasicDevice(c *GetReaderCapabilitiesResponse) (*BasicDevice, error) {
 		pwrMinToMax: pwrLvls,
 		nFreqs:      nFreqs,
 		nGPIs:       genCap.GPIOCapabilities.NumGPIs,
+		nGPOs:       genCap.GPIOCapabilities.NumGPOs,
+		nAntennas:   genCap.MaxSupportedAntennas,
 		freqInfo:    freqInfo,
 		allowsHop:   freqInfo.Hopping,
 		nSpecsPerRO: llrpCap.MaxSpecsPerROSpec,
+		nFilters:    c.C1G2LLRPCapabilities.MaxSelectFiltersPerQuery,
 		stateAware:  llrpCap.CanDoTagInventoryStateAwareSingulation,
+		hasUTCClock: genCap.HasUTCClock,
+
+		sensitivities:     slices.Clone(genCap.ReceiveSensitivities),
+		sensitivityRanges: slices.Clone(genCap.PerAntennaReceiveSensitivityRanges),
+
 		lastData: TagReportData{
 			ROSpecID:                 new(ROSpecID),
 			SpecIndex:                new(SpecIndex),
@@ -270,34 +480,213 @@ func NewImpinjDevice(c *GetReaderCapabilitiesResponse) (*ImpinjDevice, error) {
 	}
 	bd.modes = fixed
 
-	return &ImpinjDevice{BasicDevice: *bd}, nil
+	return &ImpinjDevice{
+		BasicDevice: *bd,
+		model:       ImpinjModel(c.GeneralDeviceCapabilities.Model),
+	}, nil
 }
 
+// ZebraDevice embeds BasicDevice for Zebra Readers.
+//
+// Zebra Readers follow the LLRP standard closely enough
+// to use the BasicDevice's ROSpecs and configuration,
+// so it only guards against RF modes in their capabilities they can't actually use.
+type ZebraDevice struct {
+	BasicDevice
+}
+
+func NewZebraDevice(c *GetReaderCapabilitiesResponse) (*ZebraDevice, error) {
+	bd, err := NewBasicDevice(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bd.dropUnusableModes(); err != nil {
+		return nil, err
+	}
+
+	return &ZebraDevice{BasicDevice: *bd}, nil
+}
+
+// AlienDevice embeds BasicDevice for Alien Readers.
+//
+// Like the ZebraDevice, it uses the BasicDevice's ROSpecs and configuration,
+// but guards against RF modes in their capabilities they can't actually use.
+type AlienDevice struct {
+	BasicDevice
+}
+
+func NewAlienDevice(c *GetReaderCapabilitiesResponse) (*AlienDevice, error) {
+	bd, err := NewBasicDevice(c)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bd.dropUnusableModes(); err != nil {
+		return nil, err
+	}
+
+	return &AlienDevice{BasicDevice: *bd}, nil
+}
+
+// dropUnusableModes removes RF modes without a backscatter data rate
+// or with an invalid range of Tari values,
+// as the Reader can't use them and findBestMode can't rank them.
+// It returns an error if no modes remain.
+func (d *BasicDevice) dropUnusableModes() error {
+	usable := make([]UHFC1G2RFModeTableEntry, 0, len(d.modes))
+	for _, m := range d.modes {
+		if m.BackscatterDataRate == 0 || m.MinTariTime == 0 || m.MaxTariTime < m.MinTariTime {
+			continue
+		}
+		usable = append(usable, m)
+	}
+
+	if len(usable) == 0 {
+		return errMissingCapInfo("usable RF modes",
+			"RegulatoryCapabilities", "UHFBandCapabilities", "C1G2RFModes")
+	}
+
+	d.modes = usable
+	return nil
+}
+
+// NewConfig returns the SetReaderConfig used to set up the Reader,
+// which resets it to factory defaults with the default report contents.
 func (d *BasicDevice) NewConfig() *SetReaderConfig {
-	return &SetReaderConfig{
-		ResetToFactoryDefaults: true,
-		ROReportSpec: &ROReportSpec{
-			Trigger: NTagsOrAIEnd,
-			N:       1,
-
-			TagReportContentSelector: TagReportContentSelector{
-				EnableLastSeenTimestamp: true,
-				EnableAntennaID:         true,
-				EnablePeakRSSI:          true,
-			},
+	conf, _ := d.NewReportConfig(Behavior{}) // the default report is always valid
+	conf.ResetToFactoryDefaults = true
+	return conf
+}
+
+func (d *ImpinjDevice) NewConfig() *SetReaderConfig {
+	conf, _ := d.NewReportConfig(Behavior{})
+	conf.ResetToFactoryDefaults = true
+	return conf
+}
+
+// NewReportConfig returns a SetReaderConfig that sets the Reader's tag report
+// contents and trigger to those the Behavior's Report settings select.
+func (d *BasicDevice) NewReportConfig(b Behavior) (*SetReaderConfig, error) {
+	spec := &ROReportSpec{
+		Trigger: NTagsOrAIEnd,
+		N:       1,
+
+		TagReportContentSelector: TagReportContentSelector{
+			EnableLastSeenTimestamp: true,
+			EnableAntennaID:         true,
+			EnablePeakRSSI:          true,
 		},
 	}
+
+	if r := b.Report; r != nil {
+		if r.Trigger > NMillisOrROEnd {
+			return nil, fmt.Errorf("behavior uses unknown report trigger %d: %w", r.Trigger, ErrUnsatisfiable)
+		}
+
+		if r.Trigger != None {
+			spec.Trigger, spec.N = r.Trigger, r.N
+		}
+
+		sel := &spec.TagReportContentSelector
+		sel.EnableFirstSeenTimestamp = r.FirstSeen
+		sel.EnableChannelIndex = r.ChannelIndex
+		sel.EnableTagSeenCount = r.TagSeenCount
+		sel.EnableROSpecID = r.ROSpecID
+		sel.EnableSpecIndex = r.SpecIndex
+		if r.PCBits || r.CRC {
+			sel.C1G2EPCMemorySelector = &C1G2EPCMemorySelector{
+				CRCEnabled:    r.CRC,
+				PCBitsEnabled: r.PCBits,
+			}
+		}
+	}
+
+	return &SetReaderConfig{ROReportSpec: spec}, nil
 }
 
-func (d *ImpinjDevice) NewConfig() *SetReaderConfig {
-	conf := d.BasicDevice.NewConfig()
+// NewReportConfig returns a SetReaderConfig like that of the BasicDevice,
+// but which also enables Impinj's more precise peak RSSI,
+// along with the phase angle and Doppler frequency if the Behavior's ImpinjOptions ask for them.
+// If the Behavior uses Location mode, it also sets the gateway's placement.
+func (d *ImpinjDevice) NewReportConfig(b Behavior) (*SetReaderConfig, error) {
+	conf, err := d.BasicDevice.NewReportConfig(b)
+	if err != nil {
+		return nil, err
+	}
+
+	selectors := impinjEnableBool16(ImpinjEnablePeakRSSI)
+	if opts := b.ImpinjOptions; opts != nil {
+		if opts.ReportPhaseAngle {
+			selectors = append(selectors, impinjEnableBool16(ImpinjEnableRFPhaseAngle)...)
+		}
+		if opts.ReportDoppler {
+			selectors = append(selectors, impinjEnableBool16(ImpinjEnableRFDopplerFrequency)...)
+		}
+		if opts.Location != nil {
+			conf.Custom = append(conf.Custom, opts.Location.placement())
+		}
+	}
 
 	conf.ROReportSpec.Custom = append(conf.ROReportSpec.Custom, Custom{
 		VendorID: uint32(PENImpinj),
 		Subtype:  ImpinjTagReportContentSelector,
-		Data:     impinjEnableBool16(ImpinjEnablePeakRSSI),
+		Data:     selectors,
 	})
-	return conf
+	return conf, nil
+}
+
+// NewGPOConfig returns a SetReaderConfig that sets the Reader's GPO ports,
+// or an error wrapping ErrInvalidGPO if the Reader doesn't have them.
+func (d *BasicDevice) NewGPOConfig(writes []GPOWriteData) (*SetReaderConfig, error) {
+	if len(writes) == 0 {
+		return nil, fmt.Errorf("no GPO ports to set: %w", ErrInvalidGPO)
+	}
+
+	for _, w := range writes {
+		if w.Port == 0 || w.Port > d.nGPOs {
+			return nil, fmt.Errorf("GPO port %d not in [1, %d]: %w", w.Port, d.nGPOs, ErrInvalidGPO)
+		}
+	}
+
+	return &SetReaderConfig{GPOWriteData: writes}, nil
+}
+
+// NewGPIConfig returns a SetReaderConfig that enables the Reader's GPI ports
+// and its GPIEvent notifications, so it reports when those ports change state,
+// or an error wrapping ErrInvalidGPI if the Reader doesn't have them.
+// NewConfig resets both, so they must be set again each time it's applied.
+func (d *BasicDevice) NewGPIConfig(ports []uint16) (*SetReaderConfig, error) {
+	if len(ports) == 0 {
+		return nil, fmt.Errorf("no GPI ports to enable: %w", ErrInvalidGPI)
+	}
+
+	states := make([]GPIPortCurrentState, len(ports))
+	for i, p := range ports {
+		if p == 0 || p > d.nGPIs {
+			return nil, fmt.Errorf("GPI port %d not in [1, %d]: %w", p, d.nGPIs, ErrInvalidGPI)
+		}
+		// Readers ignore the State when it's set.
+		states[i] = GPIPortCurrentState{Port: p, Enabled: true, State: GPIStateUnknown}
+	}
+
+	return &SetReaderConfig{
+		ReaderEventNotificationSpec: &ReaderEventNotificationSpec{
+			EventNotificationStates: []EventNotificationState{
+				{ReaderEventType: NotifyGPI, NotificationEnabled: true},
+			},
+		},
+		GPIPortCurrentStates: states,
+	}, nil
+}
+
+// ConfigApplied records the tag report contents set by a SetReaderConfig
+// the Reader accepted, which ProcessTagReport uses to fill in ambiguous nil parameters.
+// It must not be called concurrently with ProcessTagReport.
+func (d *BasicDevice) ConfigApplied(conf *SetReaderConfig) {
+	if conf.ROReportSpec != nil {
+		d.report = conf.ROReportSpec.TagReportContentSelector
+	}
 }
 
 // impinjEnableBool16 returns the encoding of a Custom parameter
@@ -319,6 +708,25 @@ func impinjEnableBool16(subtype ImpinjParamSubtype) []byte {
 	}
 }
 
+// impinjOptimizedRead returns the data of Impinj's EnableOptimizedRead parameter
+// that enables it and holds a C1G2Read for each MemoryRead,
+// with the same OpSpecIDs the MemoryReads' AccessSpecs would have.
+func impinjOptimizedRead(reads []MemoryRead) []byte {
+	const c1g2ReadType, c1g2ReadLen = 341, 15 // the length includes the 4 byte header
+
+	data := []byte{0, 1} // OptimizedReadMode (uint16, 0=disabled, 1=enabled)
+	for i, mr := range reads {
+		data = binary.BigEndian.AppendUint16(data, c1g2ReadType)
+		data = binary.BigEndian.AppendUint16(data, c1g2ReadLen)
+		data = binary.BigEndian.AppendUint16(data, uint16(i+1)) // #nosec G115
+		data = binary.BigEndian.AppendUint32(data, mr.AccessPassword)
+		data = append(data, uint8(mr.MemoryBank)<<6) // 2 bits, then 6 reserved
+		data = binary.BigEndian.AppendUint16(data, mr.WordAddress)
+		data = binary.BigEndian.AppendUint16(data, mr.WordCount)
+	}
+	return data
+}
+
 // ProcessTagReport processes what it expects is the most recent TagReportData.
 //
 // Currently, it fills in ambiguous nil values in the report data,
@@ -540,6 +948,342 @@ func (d *BasicDevice) findPower(target MillibelMilliwatt) (tableIdx uint16, valu
 	return t.Index, t.TransmitPowerValue
 }
 
+// findSensitivity returns the index of the Reader's receive sensitivity
+// closest to the target that's valid for the antenna.
+func (d *BasicDevice) findSensitivity(antenna AntennaID, target Decibel) (uint16, error) {
+	lo, hi := uint16(0), uint16(math.MaxUint16)
+	for _, r := range d.sensitivityRanges {
+		if r.AntennaID == antenna {
+			lo, hi = r.ReceiveSensitivityIndexMin, r.ReceiveSensitivityIndexMax
+			break
+		}
+	}
+
+	found := false
+	var bestIdx uint16
+	var bestDiff int
+	for _, s := range d.sensitivities {
+		if s.Index < lo || s.Index > hi {
+			continue
+		}
+
+		diff := int(s.ReceiveSensitivity) - int(target)
+		if diff < 0 {
+			diff = -diff
+		}
+		if !found || diff < bestDiff {
+			found, bestIdx, bestDiff = true, s.Index, diff
+		}
+	}
+
+	if !found {
+		return 0, fmt.Errorf("the Reader has no receive sensitivities for antenna %d: %w",
+			antenna, ErrUnsatisfiable)
+	}
+	return bestIdx, nil
+}
+
+// applyAntennas limits the AISpecs to the Behavior's Antennas, if it has any,
+// replacing each InventoryParameterSpec's AntennaConfiguration
+// with one per antenna that uses the antenna's settings.
+func (d *BasicDevice) applyAntennas(b Behavior, aiSpecs []AISpec) error {
+	if len(b.Antennas) == 0 {
+		return nil
+	}
+
+	ids := make([]AntennaID, len(b.Antennas))
+	confs := make([]AntennaConfiguration, len(b.Antennas))
+	seen := make(map[AntennaID]struct{}, len(b.Antennas))
+	for i, a := range b.Antennas {
+		if a.ID == 0 || (d.nAntennas != 0 && uint16(a.ID) > d.nAntennas) {
+			return fmt.Errorf("behavior uses an invalid antenna "+
+				"(%d not in [1, %d]): %w", a.ID, d.nAntennas, ErrUnsatisfiable)
+		}
+
+		if _, dup := seen[a.ID]; dup {
+			return fmt.Errorf("antenna %d is listed more than once: %w", a.ID, ErrUnsatisfiable)
+		}
+		seen[a.ID] = struct{}{}
+
+		conf := AntennaConfiguration{AntennaID: a.ID}
+		if a.Power != nil {
+			ab := b
+			ab.Power = *a.Power
+			transmit, err := d.Transmit(ab)
+			if err != nil {
+				return fmt.Errorf("antenna %d: %w", a.ID, err)
+			}
+			conf.RFTransmitter = transmit
+		}
+
+		if a.ReceiveSensitivity != nil {
+			idx, err := d.findSensitivity(a.ID, *a.ReceiveSensitivity)
+			if err != nil {
+				return err
+			}
+			receiver := RFReceiver(idx)
+			conf.RFReceiver = &receiver
+		}
+
+		ids[i] = a.ID
+		confs[i] = conf
+	}
+
+	for i := range aiSpecs {
+		aiSpecs[i].AntennaIDs = ids
+		for j := range aiSpecs[i].InventoryParameterSpecs {
+			ips := &aiSpecs[i].InventoryParameterSpecs[j]
+			base := ips.AntennaConfigurations[0]
+
+			perAntenna := make([]AntennaConfiguration, len(confs))
+			for k, conf := range confs {
+				c := base
+				c.AntennaID = conf.AntennaID
+				c.RFReceiver = conf.RFReceiver
+				if conf.RFTransmitter != nil {
+					c.RFTransmitter = conf.RFTransmitter
+				}
+				perAntenna[k] = c
+			}
+			ips.AntennaConfigurations = perAntenna
+		}
+	}
+
+	return nil
+}
+
+// applyFilters sets the Filters of the AISpecs' InventoryCommands to match the Behavior's.
+// It replaces any Filters the AISpecs already had:
+// those only exist to make the Reader send a Select,
+// which it now has to do for the Behavior's Filters anyway.
+//
+// For InventoryCommands that are TagInventoryStateAware,
+// the Filters assert the SL flag of the tags to inventory,
+// and the SingulationControl is updated to query only those tags.
+func (d *BasicDevice) applyFilters(b Behavior, aiSpecs []AISpec) error {
+	if len(b.Filters) == 0 {
+		return nil
+	}
+
+	if d.nFilters != 0 && len(b.Filters) > int(d.nFilters) {
+		return fmt.Errorf("behavior has %d filters, but the Reader supports at most %d: %w",
+			len(b.Filters), d.nFilters, ErrUnsatisfiable)
+	}
+
+	masks := make([]C1G2TagInventoryMask, len(b.Filters))
+	for i, f := range b.Filters {
+		if f.MemoryBank == MemoryBankReserved || f.MemoryBank > MemoryBankUser {
+			return fmt.Errorf("filter %d uses invalid memory bank %d: %w", i, f.MemoryBank, ErrUnsatisfiable)
+		}
+
+		if f.Action != FilterInclude && f.Action != FilterExclude {
+			return fmt.Errorf("filter %d uses unknown action %d: %w", i, f.Action, ErrUnsatisfiable)
+		}
+
+		if f.Truncate && (i != len(b.Filters)-1 || f.MemoryBank != MemoryBankEPC) {
+			return fmt.Errorf("filter %d truncates, but only the final filter "+
+				"can truncate and only in the EPC bank: %w", i, ErrUnsatisfiable)
+		}
+
+		mask, err := hex.DecodeString(f.Mask)
+		if err != nil {
+			return fmt.Errorf("filter %d has an invalid mask %q: %v: %w", i, f.Mask, err, ErrUnsatisfiable)
+		}
+
+		nBits := len(mask) * 8
+		if f.MaskBits != 0 {
+			if int(f.MaskBits) > nBits {
+				return fmt.Errorf("filter %d uses %d mask bits, but its mask only has %d: %w",
+					i, f.MaskBits, nBits, ErrUnsatisfiable)
+			}
+			nBits = int(f.MaskBits)
+			mask = mask[:(nBits+7)/8]
+		}
+
+		if nBits == 0 || nBits > math.MaxUint16 {
+			return fmt.Errorf("filter %d has a mask of %d bits: %w", i, nBits, ErrUnsatisfiable)
+		}
+
+		masks[i] = C1G2TagInventoryMask{
+			MemoryBank:         f.MemoryBank,
+			MostSignificantBit: f.BitPointer,
+			TagMaskNumBits:     uint16(nBits), // #nosec G115 -- bounded above
+			TagMask:            mask,
+		}
+	}
+
+	for _, spec := range aiSpecs {
+		for _, ips := range spec.InventoryParameterSpecs {
+			for _, ac := range ips.AntennaConfigurations {
+				cmd := ac.C1G2InventoryCommand
+				if cmd == nil {
+					continue
+				}
+
+				cmd.Filters = make([]C1G2Filter, len(b.Filters))
+				for i, f := range b.Filters {
+					cmd.Filters[i] = newFilter(f, i == 0, cmd.TagInventoryStateAware, masks[i])
+				}
+
+				if cmd.TagInventoryStateAware && cmd.SingulationControl != nil &&
+					cmd.SingulationControl.InvAwareAction != nil {
+					cmd.SingulationControl.InvAwareAction.SLState = SLStateAsserted
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// newFilter returns a C1G2Filter for the TagFilter.
+//
+// The first filter sets the SL flag of the tags it includes and clears the others';
+// later filters only clear it, so that tags must satisfy all the filters.
+func newFilter(f TagFilter, first, aware bool, mask C1G2TagInventoryMask) C1G2Filter {
+	filter := C1G2Filter{
+		TruncateAction:   FilterActionDoNotTruncate,
+		TagInventoryMask: mask,
+	}
+	if f.Truncate {
+		filter.TruncateAction = FilterActionTruncate
+	}
+
+	if aware {
+		action := AwareSelectMKeepUClear
+		switch {
+		case first && f.Action == FilterInclude:
+			action = AwareSelectMSetUClear
+		case first:
+			action = AwareSelectMClearUSet
+		case f.Action == FilterExclude:
+			action = AwareSelectMClearUKeep
+		}
+
+		filter.AwareFilterAction = &C1G2TagInventoryStateAwareFilterAction{
+			Target:       InvTargetSL,
+			FilterAction: action,
+		}
+		return filter
+	}
+
+	action := UnawareSelectMKeepUClear
+	switch {
+	case first && f.Action == FilterInclude:
+		action = UnawareSelectMSetUClear
+	case first:
+		action = UnawareSelectMClearUSet
+	case f.Action == FilterExclude:
+		action = UnawareSelectMClearUKeep
+	}
+
+	unaware := C1G2TagInventoryStateUnawareFilterAction(action)
+	filter.UnawareFilterAction = &unaware
+	return filter
+}
+
+// applySequence returns AISpecs that follow the Behavior's antenna Sequence, if it has one;
+// otherwise, it returns the given AISpecs.
+//
+// Each step gets a copy of each of the given AISpecs,
+// limited to the step's antennas and ending with the step's stop trigger.
+// If the Reader can't hold that many AISpecs in an ROSpec,
+// each step gets only a copy of the first,
+// and if it can't hold one per step,
+// there's just one AISpec that uses all the sequence's antennas.
+func (d *BasicDevice) applySequence(b Behavior, aiSpecs []AISpec) ([]AISpec, error) {
+	if len(b.Sequence) == 0 {
+		return aiSpecs, nil
+	}
+
+	enabled := make(map[AntennaID]struct{}, len(b.Antennas))
+	for _, a := range b.Antennas {
+		enabled[a.ID] = struct{}{}
+	}
+
+	var all []AntennaID
+	for i, step := range b.Sequence {
+		if len(step.Antennas) == 0 {
+			return nil, fmt.Errorf("sequence step %d has no antennas: %w", i, ErrUnsatisfiable)
+		}
+
+		if step.Dwell == 0 && step.QuietTime == 0 {
+			return nil, fmt.Errorf("sequence step %d has neither a dwell nor quiet time: %w", i, ErrUnsatisfiable)
+		}
+
+		for _, id := range step.Antennas {
+			if id == 0 || (d.nAntennas != 0 && uint16(id) > d.nAntennas) {
+				return nil, fmt.Errorf("sequence step %d uses an invalid antenna "+
+					"(%d not in [1, %d]): %w", i, id, d.nAntennas, ErrUnsatisfiable)
+			}
+
+			if _, ok := enabled[id]; len(enabled) != 0 && !ok {
+				return nil, fmt.Errorf("sequence step %d uses antenna %d, "+
+					"which isn't one of the behavior's antennas: %w", i, id, ErrUnsatisfiable)
+			}
+
+			if !slices.Contains(all, id) {
+				all = append(all, id)
+			}
+		}
+	}
+
+	// Without a Duration, the ROSpec ends once its AISpecs have all run,
+	// so unless a trigger starts it again, the sequence would only run once.
+	if b.Duration == 0 && !b.Triggered() {
+		return nil, fmt.Errorf("behavior with an antenna sequence must have a duration, "+
+			"unless it has a GPI or Periodic trigger: %w", ErrUnsatisfiable)
+	}
+
+	nSteps := uint32(len(b.Sequence)) // #nosec G115
+	switch {
+	case d.nSpecsPerRO == 0 || nSteps*uint32(len(aiSpecs)) <= d.nSpecsPerRO: // #nosec G115
+	case nSteps <= d.nSpecsPerRO:
+		aiSpecs = aiSpecs[:1]
+	default:
+		return []AISpec{withAntennas(aiSpecs[0], all, aiSpecs[0].StopTrigger)}, nil
+	}
+
+	sequence := make([]AISpec, 0, len(b.Sequence)*len(aiSpecs))
+	for _, step := range b.Sequence {
+		for _, spec := range aiSpecs {
+			sequence = append(sequence, withAntennas(spec, step.Antennas, step.stopTrigger()))
+		}
+	}
+
+	// Keep the InventoryParameterSpecIDs unique within the ROSpec.
+	id := uint16(1)
+	for i := range sequence {
+		for j := range sequence[i].InventoryParameterSpecs {
+			sequence[i].InventoryParameterSpecs[j].InventoryParameterSpecID = id
+			id++
+		}
+	}
+
+	return sequence, nil
+}
+
+// withAntennas returns a copy of the AISpec that uses only the given antennas
+// and stops with the given trigger.
+func withAntennas(spec AISpec, antennas []AntennaID, stop AISpecStopTrigger) AISpec {
+	params := make([]InventoryParameterSpec, len(spec.InventoryParameterSpecs))
+	for i, ips := range spec.InventoryParameterSpecs {
+		confs := make([]AntennaConfiguration, 0, len(ips.AntennaConfigurations))
+		for _, c := range ips.AntennaConfigurations {
+			if c.AntennaID == 0 || slices.Contains(antennas, c.AntennaID) {
+				confs = append(confs, c)
+			}
+		}
+		ips.AntennaConfigurations = confs
+		params[i] = ips
+	}
+
+	spec.AntennaIDs = antennas
+	spec.StopTrigger = stop
+	spec.InventoryParameterSpecs = params
+	return spec
+}
+
 // findBestMode returns the best RF Mode for the given environment density.
 //
 // If the number of nearby Readers is unknown, use 0.
@@ -652,19 +1396,51 @@ const (
 // Environment describes the expected operating environment.
 // For unknown values, set the field to its zero value.
 type Environment struct {
-	NumNearbyReaders uint
-	PopulationSize   uint16
-	Mobility         TagMobility
+	NumNearbyReaders uint        `json:"numNearbyReaders,omitempty"`
+	PopulationSize   uint16      `json:"populationSize,omitempty"`
+	Mobility         TagMobility `json:"mobility,omitempty"`
+
+	// AutoNearbyReaders tells a ReaderGroup to set NumNearbyReaders
+	// to the number of readers in the group.
+	AutoNearbyReaders bool `json:"autoNearbyReaders,omitempty"`
 }
 
-// NewROSpec returns a new llrp.ROSpec to achieve the Behavior within the Environment.
-func (d *BasicDevice) NewROSpec(b Behavior, e Environment) (*ROSpec, error) {
+// checkTriggers returns an error if the Reader can't satisfy the Behavior's start triggers.
+func (d *BasicDevice) checkTriggers(b Behavior) error {
 	if b.GPITrigger != nil && (b.GPITrigger.Port == 0 ||
 		d.nGPIs == 0 || b.GPITrigger.Port > d.nGPIs) {
-		return nil, fmt.Errorf("behavior uses a GPI Trigger with invalid Port "+
+		return fmt.Errorf("behavior uses a GPI Trigger with invalid Port "+
 			"(%d not in [1, %d]): %w", b.GPITrigger.Port, d.nGPIs, ErrUnsatisfiable)
 	}
 
+	pt := b.PeriodicTrigger
+	if pt == nil {
+		return nil
+	}
+
+	if b.GPITrigger != nil {
+		return fmt.Errorf("behavior can't use both a GPI and Periodic Trigger: %w", ErrUnsatisfiable)
+	}
+
+	if pt.Period != 0 && (b.Duration == 0 || b.Duration > pt.Period) {
+		return fmt.Errorf("behavior uses a Periodic Trigger with a period of %d ms, "+
+			"so its duration must be in [1, %[1]d] ms, not %d: %w", pt.Period, b.Duration, ErrUnsatisfiable)
+	}
+
+	if pt.Start != nil && !d.hasUTCClock {
+		return fmt.Errorf("behavior uses a Periodic Trigger with a start time, "+
+			"but the Reader doesn't have a UTC clock: %w", ErrUnsatisfiable)
+	}
+
+	return nil
+}
+
+// NewROSpec returns a new llrp.ROSpec to achieve the Behavior within the Environment.
+func (d *BasicDevice) NewROSpec(b Behavior, e Environment) (*ROSpec, error) {
+	if err := d.checkTriggers(b); err != nil {
+		return nil, err
+	}
+
 	transmit, err := d.Transmit(b)
 	if err != nil {
 		return nil, err
@@ -832,6 +1608,19 @@ func (d *BasicDevice) NewROSpec(b Behavior, e Environment) (*ROSpec, error) {
 		query.TagTransitTime = Millisecs32(e.Mobility)
 	}
 
+	if err := d.applyFilters(b, aiSpecs); err != nil {
+		return nil, err
+	}
+
+	if err := d.applyAntennas(b, aiSpecs); err != nil {
+		return nil, err
+	}
+
+	aiSpecs, err = d.applySequence(b, aiSpecs)
+	if err != nil {
+		return nil, err
+	}
+
 	spec := &ROSpec{
 		ROSpecID:       1, // May be overridden, but better to ensure it's not 0.
 		ROBoundarySpec: b.Boundary(),
@@ -844,10 +1633,8 @@ func (d *BasicDevice) NewROSpec(b Behavior, e Environment) (*ROSpec, error) {
 // NewROSpec returns a new llrp.ROSpec to achieve the Behavior within the Environment
 // with some aid of Impinj-specific LLRP vendor extensions.
 func (d *ImpinjDevice) NewROSpec(b Behavior, e Environment) (*ROSpec, error) {
-	if b.GPITrigger != nil && (b.GPITrigger.Port == 0 ||
-		d.nGPIs == 0 || b.GPITrigger.Port > d.nGPIs) {
-		return nil, fmt.Errorf("behavior uses a GPI Trigger with invalid Port "+
-			"(%d not in [1, %d]): %w", b.GPITrigger.Port, d.nGPIs, ErrUnsatisfiable)
+	if err := d.checkTriggers(b); err != nil {
+		return nil, err
 	}
 
 	transmit, err := d.Transmit(b)
@@ -857,6 +1644,10 @@ func (d *ImpinjDevice) NewROSpec(b Behavior, e Environment) (*ROSpec, error) {
 
 	_, best := d.findBestMode(e.NumNearbyReaders)
 
+	if opts := b.ImpinjOptions; opts != nil && (opts.Location != nil || opts.Direction != nil) {
+		return d.newGatewayROSpec(b, best)
+	}
+
 	// Impinj doesn't support state aware filtering via standard LLRP messages,
 	// but does support the concept via a custom parameter they call "Search modes".
 	queryAction := &C1G2SingulationControl{}
@@ -893,34 +1684,174 @@ func (d *ImpinjDevice) NewROSpec(b Behavior, e Environment) (*ROSpec, error) {
 		}
 	}
 
-	return &ROSpec{
-		ROSpecID:       1, // May be overridden, but better to ensure it's not 0.
-		ROBoundarySpec: b.Boundary(),
-		AISpecs: []AISpec{{
-			AntennaIDs: []AntennaID{0},
-			InventoryParameterSpecs: []InventoryParameterSpec{{
-				InventoryParameterSpecID: 1,
-				AirProtocolID:            AirProtoEPCGlobalClass1Gen2,
-				AntennaConfigurations: []AntennaConfiguration{{
-					AntennaID:     0,
-					RFTransmitter: transmit,
-					C1G2InventoryCommand: &C1G2InventoryCommand{
-						RFControl: &C1G2RFControl{
-							RFModeID: uint16(best.ModeID), // #nosec G115
-						},
-						SingulationControl: queryAction,
-						Custom: []Custom{{
-							VendorID: uint32(PENImpinj),
-							Subtype:  ImpinjSearchMode,
-							Data:     []byte{uint8(searchMode >> 8), uint8(searchMode & 0xFF)}, // #nosec G115
-						}},
+	aiSpecs := []AISpec{{
+		AntennaIDs: []AntennaID{0},
+		InventoryParameterSpecs: []InventoryParameterSpec{{
+			InventoryParameterSpecID: 1,
+			AirProtocolID:            AirProtoEPCGlobalClass1Gen2,
+			AntennaConfigurations: []AntennaConfiguration{{
+				AntennaID:     0,
+				RFTransmitter: transmit,
+				C1G2InventoryCommand: &C1G2InventoryCommand{
+					RFControl: &C1G2RFControl{
+						RFModeID: uint16(best.ModeID), // #nosec G115
 					},
-				}},
+					SingulationControl: queryAction,
+					Custom: []Custom{{
+						VendorID: uint32(PENImpinj),
+						Subtype:  ImpinjSearchMode,
+						Data:     []byte{uint8(searchMode >> 8), uint8(searchMode & 0xFF)}, // #nosec G115
+					}},
+				},
 			}},
 		}},
+	}}
+
+	if b.optimizedRead() {
+		if len(b.MemoryReads) > impinjMaxOptimizedReads {
+			return nil, fmt.Errorf("behavior has %d memory reads, but Impinj Readers "+
+				"can only perform %d with optimized read: %w",
+				len(b.MemoryReads), impinjMaxOptimizedReads, ErrUnsatisfiable)
+		}
+
+		invCmd := aiSpecs[0].InventoryParameterSpecs[0].AntennaConfigurations[0].C1G2InventoryCommand
+		invCmd.Custom = append(invCmd.Custom, Custom{
+			VendorID: uint32(PENImpinj),
+			Subtype:  ImpinjEnableOptimizedRead,
+			Data:     impinjOptimizedRead(b.MemoryReads),
+		})
+	}
+
+	// Impinj Readers don't support truncating during Select.
+	if slices.ContainsFunc(b.Filters, func(f TagFilter) bool { return f.Truncate }) {
+		return nil, fmt.Errorf("behavior has a truncating filter, "+
+			"which Impinj Readers don't support: %w", ErrUnsatisfiable)
+	}
+
+	if err := d.applyFilters(b, aiSpecs); err != nil {
+		return nil, err
+	}
+
+	if err := d.applyAntennas(b, aiSpecs); err != nil {
+		return nil, err
+	}
+
+	aiSpecs, err = d.applySequence(b, aiSpecs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ROSpec{
+		ROSpecID:       1, // May be overridden, but better to ensure it's not 0.
+		ROBoundarySpec: b.Boundary(),
+		AISpecs:        aiSpecs,
 	}, nil
 }
 
+// NewAccessSpecs returns no AccessSpecs if the Behavior uses Impinj's optimized read,
+// as its ROSpecs perform the MemoryReads;
+// otherwise, it returns those of the BasicDevice.
+func (d *ImpinjDevice) NewAccessSpecs(b Behavior) ([]AccessSpec, error) {
+	if !b.optimizedRead() {
+		return d.BasicDevice.NewAccessSpecs(b)
+	}
+	// NewROSpec limits the number of reads
+	return nil, validateMemoryReads(b.MemoryReads)
+}
+
+// NewAccessSpecs returns the AccessSpec needed to perform the Behavior's MemoryRead,
+// or nil if it doesn't have one.
+//
+// The AccessSpecID and OpSpecID are both 1.
+// The AccessSpec matches all tags and applies to any antenna
+// executing the ROSpec with the default ROSpecID.
+//
+// LLRP Readers execute at most one matching AccessSpec per tag,
+// and a TagReportData holds at most one read result,
+// so a Behavior with more than one MemoryRead is unsatisfiable;
+// reads that must all be performed on every tag
+// should be combined into a single MemoryRead, where possible.
+func (d *BasicDevice) NewAccessSpecs(b Behavior) ([]AccessSpec, error) {
+	if len(b.MemoryReads) == 0 {
+		return nil, nil
+	}
+
+	if len(b.MemoryReads) > 1 {
+		return nil, fmt.Errorf("behavior has %d memory reads, "+
+			"but Readers only perform one AccessSpec per tag: %w",
+			len(b.MemoryReads), ErrUnsatisfiable)
+	}
+
+	if err := validateMemoryReads(b.MemoryReads); err != nil {
+		return nil, err
+	}
+
+	specs := make([]AccessSpec, len(b.MemoryReads))
+	for i, mr := range b.MemoryReads {
+		id := uint16(i + 1) // #nosec G115 -- bounded by the check above
+		specs[i] = AccessSpec{
+			AccessSpecID:  uint32(id),
+			AntennaID:     0,
+			AirProtocolID: AirProtoEPCGlobalClass1Gen2,
+			ROSpecID:      defaultROSpecID,
+			Trigger:       AccessSpecStopTrigger{Trigger: AccessSpecStopTriggerNone},
+			AccessCommand: AccessCommand{
+				// A zero-length mask matches every tag.
+				C1G2TagSpec: C1G2TagSpec{TagPattern1: C1G2TargetTag{
+					C1G2MemoryBank: MemoryBankEPC,
+					MatchFlag:      true,
+				}},
+				C1G2Read: &C1G2Read{
+					OpSpecID:       id,
+					AccessPassword: mr.AccessPassword,
+					C1G2MemoryBank: mr.MemoryBank,
+					WordAddress:    mr.WordAddress,
+					WordCount:      mr.WordCount,
+				},
+			},
+		}
+	}
+
+	return specs, nil
+}
+
+// validateMemoryReads returns nil if the MemoryReads have unique names and valid memory banks.
+func validateMemoryReads(reads []MemoryRead) error {
+	seen := make(map[string]struct{}, len(reads))
+	for i, mr := range reads {
+		if mr.Name == "" {
+			return fmt.Errorf("memory read %d is missing a name: %w", i, ErrUnsatisfiable)
+		}
+
+		if _, dup := seen[mr.Name]; dup {
+			return fmt.Errorf("memory read name %q is used more than once: %w",
+				mr.Name, ErrUnsatisfiable)
+		}
+		seen[mr.Name] = struct{}{}
+
+		if mr.MemoryBank > MemoryBankUser {
+			return fmt.Errorf("memory read %q uses an invalid memory bank "+
+				"(%d not in [0, %d]): %w", mr.Name, mr.MemoryBank, MemoryBankUser, ErrUnsatisfiable)
+		}
+	}
+	return nil
+}
+
+// ReadNames returns a map of OpSpecIDs to the names of the Behavior's MemoryReads,
+// matching the OpSpecIDs used by the AccessSpecs generated for it.
+// It returns nil if the Behavior has no MemoryReads.
+func (b Behavior) ReadNames() map[uint16]string {
+	if len(b.MemoryReads) == 0 {
+		return nil
+	}
+
+	names := make(map[uint16]string, len(b.MemoryReads))
+	for i, mr := range b.MemoryReads {
+		names[uint16(i+1)] = mr.Name // #nosec G115
+	}
+	return names
+}
+
 func (b Behavior) Boundary() ROBoundarySpec {
 	return ROBoundarySpec{
 		StartTrigger: b.StartTrigger(),
@@ -932,23 +1863,41 @@ func (b Behavior) Boundary() ROBoundarySpec {
 //
 // If the Behavior includes a GPITrigger, the returned StartTrigger
 // only starts the ROSpec if the GPITrigger conditions match.
+// If it includes a PeriodicTrigger, the returned StartTrigger
+// starts the ROSpec on the trigger's schedule.
 // Otherwise, the returned StartTrigger is configured
 // so that it'll start the ROSpec immediately once Enabled.
 func (b Behavior) StartTrigger() (t ROSpecStartTrigger) {
-	if b.GPITrigger == nil {
-		if b.Duration == 0 {
-			t.Trigger = ROStartTriggerImmediate
-		} else {
-			t.Trigger = ROStartTriggerNone
-		}
-	} else {
+	switch {
+	case b.GPITrigger != nil:
 		t.Trigger = ROStartTriggerGPI
 		copyTrigger := GPITriggerValue(*b.GPITrigger)
 		t.GPITrigger = &copyTrigger
+	case b.PeriodicTrigger != nil:
+		t.Trigger = ROStartTriggerPeriodic
+		t.PeriodicTrigger = &PeriodicTriggerValue{
+			Offset: b.PeriodicTrigger.Offset,
+			Period: b.PeriodicTrigger.Period,
+		}
+		if b.PeriodicTrigger.Start != nil {
+			ts := UTCTimestamp(b.PeriodicTrigger.Start.UnixMicro()) // #nosec G115
+			t.PeriodicTrigger.UTCTimestamp = &ts
+		}
+	case b.Duration == 0:
+		t.Trigger = ROStartTriggerImmediate
+	default:
+		t.Trigger = ROStartTriggerNone
 	}
 	return
 }
 
+// Triggered returns true if the Behavior's ROSpecs wait for a GPI or Periodic trigger
+// rather than running as soon as they're started,
+// in which case Readers are idle between runs.
+func (b Behavior) Triggered() bool {
+	return b.GPITrigger != nil || b.PeriodicTrigger != nil
+}
+
 // stopTrigger returns an llrp.ROSpecStopTrigger for the Behavior.
 //
 // If the Behavior Duration is 0, this returns a StopTrigger
@@ -988,3 +1937,28 @@ func (s *ScanType) UnmarshalText(text []byte) error {
 
 	return fmt.Errorf("unknown ScanType: %q", string(text))
 }
+
+var (
+	filterActionStrs = [...][]byte{
+		FilterInclude: []byte("Include"),
+		FilterExclude: []byte("Exclude"),
+	}
+)
+
+func (a FilterAction) MarshalText() ([]byte, error) {
+	if int(a) < 0 || int(a) >= len(filterActionStrs) {
+		return nil, fmt.Errorf("unknown FilterAction: %v", a)
+	}
+	return filterActionStrs[a], nil
+}
+
+func (a *FilterAction) UnmarshalText(text []byte) error {
+	for i := range filterActionStrs {
+		if bytes.Equal(filterActionStrs[i], text) {
+			*a = FilterAction(i)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown FilterAction: %q", string(text))
+}
@@ -13,8 +13,10 @@ import (
 	"github.com/stretchr/testify/require"
 	"math"
 	"reflect"
+	"slices"
 	"testing"
 	"testing/quick"
+	"time"
 )
 
 // testROSpecProperties is a helper function
@@ -261,6 +263,52 @@ func TestImpinjDevice_invalid(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestVendorDevices(t *testing.T) {
+	newDevices := map[string]func(*GetReaderCapabilitiesResponse) (TagReader, error){
+		"Zebra": func(c *GetReaderCapabilitiesResponse) (TagReader, error) { return NewZebraDevice(c) },
+		"Alien": func(c *GetReaderCapabilitiesResponse) (TagReader, error) { return NewAlienDevice(c) },
+	}
+	capJSONs := map[string]string{"Zebra": PENZebraCap, "Alien": PENAlienCap}
+
+	for vendor, newDevice := range newDevices {
+		t.Run(vendor, func(t *testing.T) {
+			caps := &GetReaderCapabilitiesResponse{}
+			require.NoError(t, json.Unmarshal([]byte(capJSONs[vendor]), caps))
+			modeTable := &caps.RegulatoryCapabilities.UHFBandCapabilities.C1G2RFModes
+			nModes := len(modeTable.UHFC1G2RFModeTableEntries)
+			modeTable.UHFC1G2RFModeTableEntries = append(modeTable.UHFC1G2RFModeTableEntries,
+				UHFC1G2RFModeTableEntry{ModeID: 50, MinTariTime: 6250, MaxTariTime: 6250},
+				UHFC1G2RFModeTableEntry{ModeID: 51, BackscatterDataRate: 640000, MinTariTime: 25000, MaxTariTime: 6250},
+			)
+
+			d, err := newDevice(caps)
+			require.NoError(t, err)
+
+			// Other Readers keep every mode.
+			basic, err := NewBasicDevice(caps)
+			require.NoError(t, err)
+			assert.Len(t, basic.modes, nModes+2)
+
+			// These Readers aren't state aware, so a Deep scan uses a Filter instead.
+			for _, scan := range []ScanType{ScanFast, ScanNormal, ScanDeep} {
+				spec, err := d.NewROSpec(Behavior{ScanType: scan, Power: PowerTarget{Max: 3000}}, Environment{})
+				require.NoError(t, err)
+				testROSpecProperties(t, spec)
+
+				invCmd := spec.AISpecs[0].InventoryParameterSpecs[0].AntennaConfigurations[0].C1G2InventoryCommand
+				assert.False(t, invCmd.TagInventoryStateAware)
+				assert.Equal(t, scan == ScanDeep, len(invCmd.Filters) == 1)
+				require.NotNil(t, invCmd.RFControl)
+				assert.Less(t, invCmd.RFControl.RFModeID, uint16(50), "the unusable modes aren't chosen")
+			}
+
+			modeTable.UHFC1G2RFModeTableEntries = modeTable.UHFC1G2RFModeTableEntries[nModes:]
+			_, err = newDevice(caps)
+			assert.ErrorIs(t, err, ErrMissingCapInfo)
+		})
+	}
+}
+
 func TestImpinjDevice_NewConfig(t *testing.T) {
 	caps := newImpinjCaps(t)
 	d, err := NewImpinjDevice(caps)
@@ -312,6 +360,113 @@ func TestImpinjDevice_NewConfig(t *testing.T) {
 	assert.NotNil(t, d.NewConfig())
 }
 
+func TestBasicDevice_NewGPIConfig(t *testing.T) {
+	d, err := NewBasicDevice(newImpinjCaps(t))
+	require.NoError(t, err)
+
+	// NewConfig resets the GPIs and the Reader's event notifications, so they're set separately
+	conf := d.NewConfig()
+	assert.Nil(t, conf.ReaderEventNotificationSpec)
+	assert.Empty(t, conf.GPIPortCurrentStates)
+
+	conf, err = d.NewGPIConfig([]uint16{1, 4})
+	require.NoError(t, err)
+	assert.Equal(t, &SetReaderConfig{
+		ReaderEventNotificationSpec: &ReaderEventNotificationSpec{
+			EventNotificationStates: []EventNotificationState{{ReaderEventType: NotifyGPI, NotificationEnabled: true}},
+		},
+		GPIPortCurrentStates: []GPIPortCurrentState{
+			{Port: 1, Enabled: true, State: GPIStateUnknown},
+			{Port: 4, Enabled: true, State: GPIStateUnknown},
+		},
+	}, conf)
+
+	for _, ports := range [][]uint16{nil, {0}, {1, 5}} {
+		_, err = d.NewGPIConfig(ports)
+		assert.ErrorIs(t, err, ErrInvalidGPI, "expected an error for ports %v", ports)
+	}
+}
+
+func TestBasicDevice_NewReportConfig(t *testing.T) {
+	d, err := NewBasicDevice(newImpinjCaps(t))
+	require.NoError(t, err)
+
+	conf := d.NewConfig()
+	assert.True(t, conf.ResetToFactoryDefaults)
+	assert.Equal(t, NTagsOrAIEnd, conf.ROReportSpec.Trigger)
+	assert.Equal(t, uint16(1), conf.ROReportSpec.N)
+
+	conf, err = d.NewReportConfig(Behavior{Report: &ReportSettings{
+		Trigger:      NMillisOrROEnd,
+		N:            250,
+		FirstSeen:    true,
+		TagSeenCount: true,
+		CRC:          true,
+	}})
+	require.NoError(t, err)
+	assert.False(t, conf.ResetToFactoryDefaults)
+	assert.Equal(t, NMillisOrROEnd, conf.ROReportSpec.Trigger)
+	assert.Equal(t, uint16(250), conf.ROReportSpec.N)
+	sel := conf.ROReportSpec.TagReportContentSelector
+	assert.True(t, sel.EnableFirstSeenTimestamp)
+	assert.True(t, sel.EnableTagSeenCount)
+	assert.True(t, sel.EnableLastSeenTimestamp)
+	assert.False(t, sel.EnableChannelIndex)
+	assert.Equal(t, &C1G2EPCMemorySelector{CRCEnabled: true}, sel.C1G2EPCMemorySelector)
+
+	_, err = d.NewReportConfig(Behavior{Report: &ReportSettings{Trigger: NMillisOrROEnd + 1}})
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+
+	impinj, err := NewImpinjDevice(newImpinjCaps(t))
+	require.NoError(t, err)
+	conf, err = impinj.NewReportConfig(Behavior{Report: &ReportSettings{ChannelIndex: true}})
+	require.NoError(t, err)
+	assert.True(t, conf.ROReportSpec.TagReportContentSelector.EnableChannelIndex)
+	require.Len(t, conf.ROReportSpec.Custom, 1)
+	assert.True(t, conf.ROReportSpec.Custom[0].Is(PENImpinj, ImpinjTagReportContentSelector))
+	assert.Equal(t, impinjEnableBool16(ImpinjEnablePeakRSSI), conf.ROReportSpec.Custom[0].Data)
+
+	conf, err = impinj.NewReportConfig(Behavior{ImpinjOptions: &ImpinjOptions{
+		ReportPhaseAngle: true,
+		ReportDoppler:    true,
+	}})
+	require.NoError(t, err)
+	require.Len(t, conf.ROReportSpec.Custom, 1)
+	data := conf.ROReportSpec.Custom[0].Data
+	require.Len(t, data, 3*14)
+	assert.Equal(t, impinjEnableBool16(ImpinjEnableRFPhaseAngle), data[14:28])
+	assert.Equal(t, impinjEnableBool16(ImpinjEnableRFDopplerFrequency), data[28:])
+}
+
+func TestBasicDevice_ProcessTagReport(t *testing.T) {
+	d, err := NewBasicDevice(newImpinjCaps(t))
+	require.NoError(t, err)
+
+	conf, err := d.NewReportConfig(Behavior{Report: &ReportSettings{ChannelIndex: true}})
+	require.NoError(t, err)
+	d.ConfigApplied(conf)
+
+	antenna, rssi, channel := AntennaID(2), PeakRSSI(-50), ChannelIndex(7)
+	seen := LastSeenUTC(1000)
+	tags := []TagReportData{
+		{AntennaID: &antenna, PeakRSSI: &rssi, ChannelIndex: &channel, LastSeenUTC: &seen},
+		{},
+	}
+	d.ProcessTagReport(tags)
+
+	// Enabled parameters missing from the report match the last reported values,
+	// but those that aren't enabled stay nil.
+	second := tags[1]
+	require.NotNil(t, second.AntennaID)
+	assert.Equal(t, antenna, *second.AntennaID)
+	require.NotNil(t, second.ChannelIndex)
+	assert.Equal(t, channel, *second.ChannelIndex)
+	require.NotNil(t, second.LastSeenUTC)
+	assert.Equal(t, seen, *second.LastSeenUTC)
+	assert.Nil(t, second.FirstSeenUTC)
+	assert.Nil(t, second.TagSeenCount)
+}
+
 func TestBasicDevice_NewROSpec(t *testing.T) {
 	caps := newImpinjCaps(t)
 	d, err := NewBasicDevice(caps)
@@ -350,6 +505,207 @@ func TestBasicDevice_NewROSpec(t *testing.T) {
 	}
 }
 
+func TestNewROSpec_antennas(t *testing.T) {
+	caps := newImpinjCaps(t)
+	basic, err := NewBasicDevice(caps)
+	require.NoError(t, err)
+	impinj, err := NewImpinjDevice(caps)
+	require.NoError(t, err)
+
+	sensitivity := Decibel(22)
+	b := Behavior{
+		ScanType: ScanDeep,
+		Power:    PowerTarget{Max: 3000},
+		Antennas: []AntennaSettings{
+			{ID: 1},
+			{ID: 3, Power: &PowerTarget{Max: 1500}, ReceiveSensitivity: &sensitivity},
+		},
+	}
+
+	for _, d := range []TagReader{basic, impinj} {
+		spec, err := d.NewROSpec(b, Environment{})
+		require.NoError(t, err)
+		testROSpecProperties(t, spec)
+
+		for _, ai := range spec.AISpecs {
+			assert.Equal(t, []AntennaID{1, 3}, ai.AntennaIDs)
+			for _, ips := range ai.InventoryParameterSpecs {
+				require.Len(t, ips.AntennaConfigurations, 2)
+				all, aisle := ips.AntennaConfigurations[0], ips.AntennaConfigurations[1]
+				assert.Equal(t, AntennaID(1), all.AntennaID)
+				assert.Nil(t, all.RFReceiver)
+				assert.Equal(t, AntennaID(3), aisle.AntennaID)
+				require.NotNil(t, aisle.RFReceiver)
+				assert.Equal(t, RFReceiver(14), *aisle.RFReceiver) // the entry for 22 dB
+				assert.Less(t, aisle.RFTransmitter.TransmitPowerIndex, all.RFTransmitter.TransmitPowerIndex)
+				assert.Same(t, all.C1G2InventoryCommand, aisle.C1G2InventoryCommand)
+			}
+		}
+	}
+
+	for _, antennas := range [][]AntennaSettings{
+		{{ID: 0}},
+		{{ID: 5}},
+		{{ID: 1}, {ID: 1}},
+		{{ID: 2, Power: &PowerTarget{Max: 30}}},
+	} {
+		_, err := basic.NewROSpec(Behavior{Power: PowerTarget{Max: 3000}, Antennas: antennas}, Environment{})
+		assert.ErrorIs(t, err, ErrUnsatisfiable)
+	}
+}
+
+func TestNewROSpec_sequence(t *testing.T) {
+	caps := newImpinjCaps(t)
+	basic, err := NewBasicDevice(caps)
+	require.NoError(t, err)
+
+	b := Behavior{
+		ScanType: ScanNormal,
+		Duration: 60000,
+		Power:    PowerTarget{Max: 3000},
+		Sequence: []AntennaStep{
+			{Antennas: []AntennaID{2}, Dwell: 500},
+			{Antennas: []AntennaID{1, 3}, Dwell: 2000, QuietTime: 100},
+		},
+	}
+
+	spec, err := basic.NewROSpec(b, Environment{})
+	require.NoError(t, err)
+	testROSpecProperties(t, spec)
+	require.Len(t, spec.AISpecs, 2)
+
+	first, second := spec.AISpecs[0], spec.AISpecs[1]
+	assert.Equal(t, []AntennaID{2}, first.AntennaIDs)
+	assert.Equal(t, AISpecStopTrigger{Trigger: AIStopTriggerDuration, DurationTriggerValue: 500}, first.StopTrigger)
+	assert.Equal(t, []AntennaID{1, 3}, second.AntennaIDs)
+	assert.Equal(t, AIStopTriggerTagObservation, second.StopTrigger.Trigger)
+	require.NotNil(t, second.StopTrigger.TagObservationTrigger)
+	assert.Equal(t, TagObservationTrigger{Trigger: TagObsTriggerNoNewAfterT, T: 100, Timeout: 2000},
+		*second.StopTrigger.TagObservationTrigger)
+	assert.NotEqual(t, first.InventoryParameterSpecs[0].InventoryParameterSpecID,
+		second.InventoryParameterSpecs[0].InventoryParameterSpecID)
+
+	// A reader that can't hold a spec per step scans all the antennas at once.
+	basic.nSpecsPerRO = 1
+	spec, err = basic.NewROSpec(b, Environment{})
+	require.NoError(t, err)
+	require.Len(t, spec.AISpecs, 1)
+	assert.Equal(t, []AntennaID{2, 1, 3}, spec.AISpecs[0].AntennaIDs)
+	assert.Equal(t, AIStopTriggerNone, spec.AISpecs[0].StopTrigger.Trigger)
+
+	for _, seq := range [][]AntennaStep{
+		{{Dwell: 100}},
+		{{Antennas: []AntennaID{1}}},
+		{{Antennas: []AntennaID{0}, Dwell: 100}},
+		{{Antennas: []AntennaID{5}, Dwell: 100}},
+	} {
+		_, err := basic.NewROSpec(Behavior{Duration: 60000, Power: PowerTarget{Max: 3000}, Sequence: seq}, Environment{})
+		assert.ErrorIs(t, err, ErrUnsatisfiable)
+	}
+
+	_, err = basic.NewROSpec(Behavior{
+		Duration: 60000,
+		Power:    PowerTarget{Max: 3000},
+		Antennas: []AntennaSettings{{ID: 1}},
+		Sequence: []AntennaStep{{Antennas: []AntennaID{2}, Dwell: 100}},
+	}, Environment{})
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+
+	// Without a Duration, the ROSpec would end after one pass through the sequence,
+	// so it needs a trigger to start it again.
+	b.Duration = 0
+	_, err = basic.NewROSpec(b, Environment{})
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+
+	b.GPITrigger = &GPITrigger{Port: 1, Event: true}
+	spec, err = basic.NewROSpec(b, Environment{})
+	require.NoError(t, err)
+	assert.Equal(t, ROStartTriggerGPI, spec.ROBoundarySpec.StartTrigger.Trigger)
+	assert.Equal(t, ROStopTriggerNone, spec.ROBoundarySpec.StopTrigger.Trigger)
+}
+
+func TestNewROSpec_filters(t *testing.T) {
+	caps := newImpinjCaps(t)
+	basic, err := NewBasicDevice(caps)
+	require.NoError(t, err)
+	impinj, err := NewImpinjDevice(caps)
+	require.NoError(t, err)
+
+	b := Behavior{
+		ScanType: ScanNormal,
+		Power:    PowerTarget{Max: 3000},
+		Filters: []TagFilter{
+			{MemoryBank: MemoryBankEPC, BitPointer: 32, Mask: "3008", MaskBits: 12},
+			{MemoryBank: MemoryBankTID, Mask: "e280", Action: FilterExclude},
+		},
+	}
+
+	basic.stateAware = true
+	spec, err := basic.NewROSpec(b, Environment{})
+	require.NoError(t, err)
+	testROSpecProperties(t, spec)
+	cmd := spec.AISpecs[0].InventoryParameterSpecs[0].AntennaConfigurations[0].C1G2InventoryCommand
+	require.Len(t, cmd.Filters, 2)
+	assert.Equal(t, C1G2TagInventoryMask{
+		MemoryBank: MemoryBankEPC, MostSignificantBit: 32, TagMaskNumBits: 12, TagMask: []byte{0x30, 0x08},
+	}, cmd.Filters[0].TagInventoryMask)
+	assert.Equal(t, AwareSelectMSetUClear, cmd.Filters[0].AwareFilterAction.FilterAction)
+	assert.Equal(t, AwareSelectMClearUKeep, cmd.Filters[1].AwareFilterAction.FilterAction)
+	assert.Equal(t, SLStateAsserted, cmd.SingulationControl.InvAwareAction.SLState)
+
+	spec, err = impinj.NewROSpec(b, Environment{})
+	require.NoError(t, err)
+	cmd = spec.AISpecs[0].InventoryParameterSpecs[0].AntennaConfigurations[0].C1G2InventoryCommand
+	require.Len(t, cmd.Filters, 2)
+	assert.Nil(t, cmd.Filters[0].AwareFilterAction)
+	assert.Equal(t, C1G2TagInventoryStateUnawareFilterAction(UnawareSelectMSetUClear), *cmd.Filters[0].UnawareFilterAction)
+	assert.Equal(t, C1G2TagInventoryStateUnawareFilterAction(UnawareSelectMClearUKeep), *cmd.Filters[1].UnawareFilterAction)
+
+	truncate := []TagFilter{{MemoryBank: MemoryBankEPC, BitPointer: 32, Mask: "30", Truncate: true}}
+	_, err = basic.NewROSpec(Behavior{Power: PowerTarget{Max: 3000}, Filters: truncate}, Environment{})
+	assert.NoError(t, err)
+	_, err = impinj.NewROSpec(Behavior{Power: PowerTarget{Max: 3000}, Filters: truncate}, Environment{})
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+
+	for _, filters := range [][]TagFilter{
+		{{MemoryBank: MemoryBankEPC, Mask: "30"}, {MemoryBank: MemoryBankEPC, Mask: "30"}, {MemoryBank: MemoryBankEPC, Mask: "30"}},
+		{{MemoryBank: MemoryBankReserved, Mask: "30"}},
+		{{MemoryBank: MemoryBankEPC, Mask: "xyz"}},
+		{{MemoryBank: MemoryBankEPC, Mask: ""}},
+		{{MemoryBank: MemoryBankEPC, Mask: "30", MaskBits: 9}},
+		{{MemoryBank: MemoryBankEPC, Mask: "30", Action: 7}},
+		{{MemoryBank: MemoryBankTID, Mask: "30", Truncate: true}},
+		{{MemoryBank: MemoryBankEPC, Mask: "30", Truncate: true}, {MemoryBank: MemoryBankEPC, Mask: "30"}},
+	} {
+		_, err := basic.NewROSpec(Behavior{Power: PowerTarget{Max: 3000}, Filters: filters}, Environment{})
+		assert.ErrorIs(t, err, ErrUnsatisfiable)
+	}
+}
+
+func TestFindSensitivity(t *testing.T) {
+	d := BasicDevice{
+		sensitivities: []ReceiveSensitivityTableEntry{
+			{Index: 1, ReceiveSensitivity: 0},
+			{Index: 2, ReceiveSensitivity: 10},
+			{Index: 3, ReceiveSensitivity: 20},
+		},
+		sensitivityRanges: []PerAntennaReceiveSensitivityRange{
+			{AntennaID: 2, ReceiveSensitivityIndexMin: 1, ReceiveSensitivityIndexMax: 2},
+		},
+	}
+
+	idx, err := d.findSensitivity(1, 16)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(3), idx)
+
+	idx, err = d.findSensitivity(2, 16)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(2), idx, "antenna 2 is limited to the first two entries")
+
+	_, err = (&BasicDevice{}).findSensitivity(1, 0)
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+}
+
 func TestBasicDevice_NewROSpec_noHopThisTime(t *testing.T) {
 	caps := newImpinjCaps(t)
 	freqInfo := &caps.RegulatoryCapabilities.UHFBandCapabilities.FrequencyInformation
@@ -405,6 +761,123 @@ func TestBasicDevice_NewROSpec_noHopThisTime(t *testing.T) {
 	}
 }
 
+func TestBasicDevice_NewAccessSpecs(t *testing.T) {
+	d, err := NewBasicDevice(newImpinjCaps(t))
+	require.NoError(t, err)
+
+	specs, err := d.NewAccessSpecs(Behavior{})
+	assert.NoError(t, err)
+	assert.Nil(t, specs)
+
+	b := Behavior{MemoryReads: []MemoryRead{
+		{Name: "user", MemoryBank: MemoryBankUser, WordAddress: 2, WordCount: 4, AccessPassword: 0xDEADBEEF},
+	}}
+	specs, err = d.NewAccessSpecs(b)
+	require.NoError(t, err)
+	require.Len(t, specs, 1)
+
+	names := b.ReadNames()
+	for i, spec := range specs {
+		mr := b.MemoryReads[i]
+		assert.Equal(t, uint32(i+1), spec.AccessSpecID)
+		assert.Equal(t, uint32(defaultROSpecID), spec.ROSpecID)
+		assert.Equal(t, AirProtoEPCGlobalClass1Gen2, spec.AirProtocolID)
+		assert.Zero(t, spec.AccessCommand.C1G2TagSpec.TagPattern1.TagMaskNumBits)
+
+		read := spec.AccessCommand.C1G2Read
+		require.NotNil(t, read)
+		assert.Equal(t, mr.Name, names[read.OpSpecID])
+		assert.Equal(t, mr.MemoryBank, read.C1G2MemoryBank)
+		assert.Equal(t, mr.WordAddress, read.WordAddress)
+		assert.Equal(t, mr.WordCount, read.WordCount)
+		assert.Equal(t, mr.AccessPassword, read.AccessPassword)
+	}
+
+	for _, b := range []Behavior{
+		{MemoryReads: []MemoryRead{{MemoryBank: MemoryBankTID}}},
+		{MemoryReads: []MemoryRead{{Name: "a"}, {Name: "a"}}},
+		{MemoryReads: []MemoryRead{{Name: "a", MemoryBank: 4}}},
+		// a Reader only performs the first matching AccessSpec for each tag
+		{MemoryReads: []MemoryRead{{Name: "a"}, {Name: "b"}}},
+	} {
+		_, err := d.NewAccessSpecs(b)
+		assert.ErrorIs(t, err, ErrUnsatisfiable, "expected an error for behavior %+v", b)
+	}
+}
+
+func TestImpinjDevice_optimizedRead(t *testing.T) {
+	d, err := NewImpinjDevice(newImpinjCaps(t))
+	require.NoError(t, err)
+
+	b := Behavior{
+		Power:         PowerTarget{Max: 3000},
+		ImpinjOptions: &ImpinjOptions{OptimizedRead: true},
+		MemoryReads: []MemoryRead{
+			{Name: "user", MemoryBank: MemoryBankUser, WordAddress: 2, WordCount: 2, AccessPassword: 0xDEADBEEF},
+		},
+	}
+
+	specs, err := d.NewAccessSpecs(b)
+	require.NoError(t, err)
+	assert.Nil(t, specs)
+
+	spec, err := d.NewROSpec(b, Environment{})
+	require.NoError(t, err)
+	invCmd := spec.AISpecs[0].InventoryParameterSpecs[0].AntennaConfigurations[0].C1G2InventoryCommand
+	idx := slices.IndexFunc(invCmd.Custom, func(c Custom) bool {
+		return c.Is(PENImpinj, ImpinjEnableOptimizedRead)
+	})
+	require.GreaterOrEqual(t, idx, 0)
+	assert.Equal(t, []byte{
+		0, 1,
+		0x01, 0x55, 0, 15, 0, 1, 0xDE, 0xAD, 0xBE, 0xEF, 0xC0, 0, 2, 0, 2,
+	}, invCmd.Custom[idx].Data)
+
+	// The Reader reports each tag's result with the read's OpSpecID,
+	// in the TagReportData's only C1G2ReadOpSpecResult.
+	var report ROAccessReport
+	require.NoError(t, json.Unmarshal([]byte(`{"TagReportData": [
+		{"EPC96": {"EPC": "MAAAAAAAAAAAAAAB"},
+		 "C1G2ReadOpSpecResult": {"C1G2ReadOpSpecResultType": 0, "OpSpecID": 1, "Data": [4660, 22136]}},
+		{"EPC96": {"EPC": "MAAAAAAAAAAAAAAC"},
+		 "C1G2ReadOpSpecResult": {"C1G2ReadOpSpecResultType": 0, "OpSpecID": 1, "Data": [43981, 61185]}}
+	]}`), &report))
+	require.Len(t, report.TagReportData, 2)
+
+	names := b.ReadNames()
+	for i, want := range []struct{ epc, data string }{
+		{"300000000000000000000001", "12345678"},
+		{"300000000000000000000002", "abcdef01"},
+	} {
+		rt := &report.TagReportData[i]
+		assert.Equal(t, want.epc, rt.EPCAsHex())
+		require.NotNil(t, rt.C1G2ReadOpSpecResult)
+		assert.Equal(t, "user", names[rt.C1G2ReadOpSpecResult.OpSpecID])
+		data, ok := rt.ReadDataAsHex()
+		assert.True(t, ok)
+		assert.Equal(t, want.data, data)
+	}
+
+	// Since a TagReportData can't hold a second result, the Reader performs only one read.
+	b.MemoryReads = append(b.MemoryReads, MemoryRead{Name: "tid", MemoryBank: MemoryBankTID, WordCount: 6})
+	_, err = d.NewROSpec(b, Environment{})
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+
+	// Without the option, the Reader uses AccessSpecs, which also perform one read.
+	b.ImpinjOptions = nil
+	_, err = d.NewAccessSpecs(b)
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+	b.MemoryReads = b.MemoryReads[:1]
+	specs, err = d.NewAccessSpecs(b)
+	require.NoError(t, err)
+	assert.Len(t, specs, 1)
+
+	b.ImpinjOptions = &ImpinjOptions{OptimizedRead: true}
+	b.MemoryReads = []MemoryRead{{Name: "a", MemoryBank: 4}}
+	_, err = d.NewAccessSpecs(b)
+	assert.ErrorIs(t, err, ErrUnsatisfiable, "optimized reads are still validated")
+}
+
 func TestFastestAt(t *testing.T) {
 	caps := newImpinjCaps(t)
 	d, err := NewImpinjDevice(caps)
@@ -482,6 +955,61 @@ func TestBehavior_Boundary_zero(t *testing.T) {
 	bound = b.Boundary()
 	checkStartGPI(t, bound)
 	checkStopNone(t, bound)
+
+	b.GPITrigger = nil
+	b.Duration = 5000
+	b.PeriodicTrigger = &PeriodicTrigger{Period: 60000}
+	bound = b.Boundary()
+	checkStartPeriodic(t, bound)
+	checkStopDuration(t, bound)
+	assert.Equal(t, Millisecs32(60000), bound.StartTrigger.PeriodicTrigger.Period)
+	assert.Nil(t, bound.StartTrigger.PeriodicTrigger.UTCTimestamp)
+
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	b.PeriodicTrigger.Start = &start
+	bound = b.Boundary()
+	require.NotNil(t, bound.StartTrigger.PeriodicTrigger.UTCTimestamp)
+	assert.Equal(t, UTCTimestamp(start.UnixMicro()), *bound.StartTrigger.PeriodicTrigger.UTCTimestamp)
+}
+
+func TestNewROSpec_periodic(t *testing.T) {
+	caps := newImpinjCaps(t)
+	basic, err := NewBasicDevice(caps)
+	require.NoError(t, err)
+	impinj, err := NewImpinjDevice(caps)
+	require.NoError(t, err)
+
+	start := time.Now()
+	valid := []Behavior{
+		{Duration: 5000, PeriodicTrigger: &PeriodicTrigger{Period: 60000}},
+		{Duration: 5000, PeriodicTrigger: &PeriodicTrigger{Offset: 1000, Period: 5000, Start: &start}},
+		{PeriodicTrigger: &PeriodicTrigger{Offset: 1000}},
+	}
+	invalid := []Behavior{
+		{PeriodicTrigger: &PeriodicTrigger{Period: 60000}},
+		{Duration: 5001, PeriodicTrigger: &PeriodicTrigger{Period: 5000}},
+		{Duration: 5000, PeriodicTrigger: &PeriodicTrigger{Period: 60000}, GPITrigger: &GPITrigger{Port: 1}},
+	}
+
+	for _, d := range []TagReader{basic, impinj} {
+		for _, b := range valid {
+			b.Power = PowerTarget{Max: 3000}
+			spec, err := d.NewROSpec(b, Environment{})
+			require.NoError(t, err)
+			testROSpecProperties(t, spec)
+			checkStartPeriodic(t, spec.ROBoundarySpec)
+		}
+
+		for _, b := range invalid {
+			b.Power = PowerTarget{Max: 3000}
+			_, err := d.NewROSpec(b, Environment{})
+			assert.ErrorIs(t, err, ErrUnsatisfiable)
+		}
+	}
+
+	basic.hasUTCClock = false
+	_, err = basic.NewROSpec(Behavior{Power: PowerTarget{Max: 3000}, PeriodicTrigger: &PeriodicTrigger{Start: &start}}, Environment{})
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
 }
 
 func checkStartImmediate(t *testing.T, spec ROBoundarySpec) {
@@ -505,6 +1033,13 @@ func checkStartGPI(t *testing.T, spec ROBoundarySpec) {
 	require.Nil(t, spec.StartTrigger.PeriodicTrigger)
 }
 
+func checkStartPeriodic(t *testing.T, spec ROBoundarySpec) {
+	t.Helper()
+	require.Equal(t, spec.StartTrigger.Trigger, ROStartTriggerPeriodic)
+	require.Nil(t, spec.StartTrigger.GPITrigger)
+	require.NotNil(t, spec.StartTrigger.PeriodicTrigger)
+}
+
 func checkStopNone(t *testing.T, spec ROBoundarySpec) {
 	t.Helper()
 	require.Equal(t, spec.StopTrigger.Trigger, ROStopTriggerNone)
@@ -538,6 +1073,9 @@ func TestMarshalBehaviorText(t *testing.T) {
 		{"normal", ScanNormal, []byte(`"Normal"`), false},
 		{"deep", ScanDeep, []byte(`"Deep"`), false},
 		{"unknownScan", ScanType(501), nil, true},
+		{"include", FilterInclude, []byte(`"Include"`), false},
+		{"exclude", FilterExclude, []byte(`"Exclude"`), false},
+		{"unknownFilterAction", FilterAction(3), nil, true},
 	}
 	for _, testCase := range tests {
 		testCase := testCase
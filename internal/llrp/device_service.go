@@ -29,6 +29,11 @@ const (
 	startCmd     = "startROSpec"
 	deleteCmd    = "deleteROSpec"
 
+	addAccessCmd     = "AccessSpec"
+	enableAccessCmd  = "enableAccessSpec"
+	disableAccessCmd = "disableAccessSpec"
+	deleteAccessCmd  = "deleteAccessSpec"
+
 	enableImpinjCmd = "EnableImpinjExtensions"
 
 	capReadingName = "ReaderCapabilities"
@@ -78,20 +83,50 @@ func (ds DSClient) NewReader(device string) (TagReader, error) {
 			return nil, err
 		}
 
-		if err := ds.SetConfig(device, impDev.NewConfig()); err != nil {
+		conf := impDev.NewConfig()
+		if err := ds.SetConfig(device, conf); err != nil {
 			return nil, err
 		}
+		impDev.ConfigApplied(conf)
 
 		tr = impDev
+	case PENZebra:
+		zebra, err := NewZebraDevice(devCap)
+		if err != nil {
+			return nil, err
+		}
+
+		conf := zebra.NewConfig()
+		if err := ds.SetConfig(device, conf); err != nil {
+			return nil, err
+		}
+		zebra.ConfigApplied(conf)
+
+		tr = zebra
+	case PENAlien:
+		alien, err := NewAlienDevice(devCap)
+		if err != nil {
+			return nil, err
+		}
+
+		conf := alien.NewConfig()
+		if err := ds.SetConfig(device, conf); err != nil {
+			return nil, err
+		}
+		alien.ConfigApplied(conf)
+
+		tr = alien
 	default:
 		basic, err := NewBasicDevice(devCap)
 		if err != nil {
 			return nil, err
 		}
 
-		if err := ds.SetConfig(device, basic.NewConfig()); err != nil {
+		conf := basic.NewConfig()
+		if err := ds.SetConfig(device, conf); err != nil {
 			return nil, err
 		}
+		basic.ConfigApplied(conf)
 
 		tr = basic
 	}
@@ -237,6 +272,68 @@ func (ds DSClient) modifyROSpecState(roCmd, device string, id uint32) error {
 	return nil
 }
 
+// AddAccessSpec adds an AccessSpec on the given device.
+func (ds DSClient) AddAccessSpec(device string, spec *AccessSpec) error {
+	accessData, err := json.Marshal(spec)
+	if err != nil {
+		return fmt.Errorf("failed to marshal AccessSpec: %w", err)
+	}
+
+	var accessMapData map[string]interface{}
+	err = json.Unmarshal(accessData, &accessMapData)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal AccessSpec: %w", err)
+	}
+
+	commandData := map[string]interface{}{
+		"AccessSpec": accessMapData,
+	}
+
+	ds.lc.Debugf("Sending SET command '%s' to device '%s' with data '%v'", addAccessCmd, device, commandData)
+
+	_, err = ds.cmdClient.IssueSetCommandByName(context.Background(), device, addAccessCmd, commandData)
+	if err != nil {
+		return fmt.Errorf("failed to add AccessSpec: %v", err)
+	}
+	return nil
+}
+
+// EnableAccessSpec enables the AccessSpec with the given ID on the given device.
+func (ds DSClient) EnableAccessSpec(device string, id uint32) error {
+	return ds.modifyAccessSpecState(enableAccessCmd, device, id)
+}
+
+// DisableAccessSpec disables the AccessSpec with the given ID on the given device.
+func (ds DSClient) DisableAccessSpec(device string, id uint32) error {
+	return ds.modifyAccessSpecState(disableAccessCmd, device, id)
+}
+
+// DeleteAccessSpec deletes the AccessSpec with the given ID on the given device.
+func (ds DSClient) DeleteAccessSpec(device string, id uint32) error {
+	return ds.modifyAccessSpecState(deleteAccessCmd, device, id)
+}
+
+// DeleteAllAccessSpecs deletes all the AccessSpecs on the given device.
+func (ds DSClient) DeleteAllAccessSpecs(device string) error {
+	return ds.modifyAccessSpecState(deleteAccessCmd, device, 0)
+}
+
+// modifyAccessSpecState requests to set the given device's AccessSpec to a particular state.
+func (ds DSClient) modifyAccessSpecState(accessCmd, device string, id uint32) error {
+	data := make(map[string]any)
+
+	data["AccessSpecID"] = strconv.FormatUint(uint64(id), 10)
+
+	ds.lc.Debugf("Sending SET command '%s' to device '%s' with data '%v'", accessCmd, device, data)
+
+	_, err := ds.cmdClient.IssueSetCommandByName(context.Background(), device, accessCmd, data)
+	if err != nil {
+		return fmt.Errorf("failed to "+accessCmd+": %v", err)
+	}
+
+	return nil
+}
+
 // EnableCustomExt enables custom Impinj extensions.
 // Note that the device in question must be registered
 // with a device profile that has an enableImpinjExt deviceCommand.
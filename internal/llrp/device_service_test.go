@@ -4,7 +4,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
-	"reflect"
 	"testing"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v4/clients/interfaces/mocks"
@@ -41,6 +40,7 @@ func TestNewReader(t *testing.T) {
 		deviceName   string
 		respCode     int
 		capabilities map[string]interface{}
+		expected     TagReader
 	}
 
 	penICap := createMockCapabilities(t, PENImpinjCap)
@@ -53,26 +53,28 @@ func TestNewReader(t *testing.T) {
 			deviceName:   "SpeedwayR-19-FE-16",
 			respCode:     http.StatusOK,
 			capabilities: penICap,
+			expected:     &ImpinjDevice{},
 		},
 		{
-			testCaseName: "Test New Reader Type for Device of Type PENImpinj",
+			testCaseName: "Test New Reader Type for Device of Type PENAlien",
 			deviceName:   "SpeedwayR-19-FE-16",
 			respCode:     http.StatusOK,
 			capabilities: penACap,
+			expected:     &AlienDevice{},
 		},
 		{
 			testCaseName: "Test New Reader Type for Device of Type PENZebra",
 			deviceName:   "SpeedwayR-19-FE-16",
 			respCode:     http.StatusOK,
 			capabilities: penZCap,
+			expected:     &ZebraDevice{},
 		},
 	}
 
-	mockClient := &mocks.CommandClient{}
-	deviceServiceClient := NewDSClient(mockClient, getTestingLogger())
-
 	for _, tc := range testCases {
 		t.Run(tc.testCaseName, func(tt *testing.T) {
+			mockClient := &mocks.CommandClient{}
+			deviceServiceClient := NewDSClient(mockClient, getTestingLogger())
 
 			tcEvent := dtos.NewEvent("a", tc.deviceName, capReadingName)
 			tcEvent.AddObjectReading(capReadingName, tc.capabilities)
@@ -81,22 +83,9 @@ func TestNewReader(t *testing.T) {
 			mockClient.On("IssueGetCommandByName", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(&mockResp, nil)
 			mockClient.On("IssueSetCommandByName", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(common.BaseResponse{}, nil)
 
-			getReaderCapabilitiesResponse, err := deviceServiceClient.GetCapabilities(tc.deviceName)
-			require.NotNil(tt, getReaderCapabilitiesResponse, "err %s", err)
-
-			var deviceType interface{}
-			switch VendorPEN(getReaderCapabilitiesResponse.GeneralDeviceCapabilities.DeviceManufacturer) {
-			case PENImpinj:
-				deviceType, err = NewImpinjDevice(getReaderCapabilitiesResponse)
-				require.NoError(t, err)
-			default:
-				deviceType, err = NewBasicDevice(getReaderCapabilitiesResponse)
-				require.NoError(t, err)
-			}
-
 			tagReader, err := deviceServiceClient.NewReader(tc.deviceName)
-			require.NoError(t, err)
-			require.Equal(tt, reflect.TypeOf(tagReader), reflect.TypeOf(deviceType))
+			require.NoError(tt, err)
+			require.IsType(tt, tc.expected, tagReader)
 		})
 	}
 
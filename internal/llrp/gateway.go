@@ -0,0 +1,420 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llrp
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// ImpinjLocation configures an xArray gateway's Location mode,
+// in which it estimates the XY coordinates of the tags beneath it.
+//
+// The interval values are in seconds; if 0, the gateway's defaults are used.
+// The placement describes where the gateway is mounted,
+// so it can report coordinates relative to the facility rather than itself.
+type ImpinjLocation struct {
+	// ComputeWindow is how long the gateway collects reads for each estimate.
+	ComputeWindow uint16 `json:"computeWindow,omitempty"`
+	// TagAgeInterval is how long a tag must go unread before the gateway reports it exited.
+	TagAgeInterval uint16 `json:"tagAgeInterval,omitempty"`
+	// UpdateInterval is how often the gateway reports the positions of tags it's tracking.
+	UpdateInterval uint16 `json:"updateInterval,omitempty"`
+
+	// Height is the gateway's height above the tags, in centimeters.
+	Height uint16 `json:"height"`
+	// FacilityX and FacilityY are the gateway's facility coordinates, in centimeters.
+	FacilityX int32 `json:"facilityX,omitempty"`
+	FacilityY int32 `json:"facilityY,omitempty"`
+	// Orientation is the gateway's rotation relative to the facility's axes, in degrees.
+	Orientation int16 `json:"orientation,omitempty"`
+}
+
+// ImpinjDirection configures an xArray or xSpan gateway's Direction mode,
+// in which it reports the direction tags travel through its sectors.
+//
+// The interval values are in seconds; if 0, the gateway's defaults are used.
+type ImpinjDirection struct {
+	// Sectors are the IDs of the sectors the gateway watches;
+	// there must be at least two, so a tag's travel between them can be observed.
+	Sectors []uint8 `json:"sectors"`
+	// TagAgeInterval is how long a tag must go unread before the gateway reports it exited.
+	TagAgeInterval uint16 `json:"tagAgeInterval,omitempty"`
+	// UpdateInterval is how often the gateway reports the tags it's tracking.
+	UpdateInterval uint16 `json:"updateInterval,omitempty"`
+	// FieldOfView limits how far from the gateway it tracks tags.
+	FieldOfView FieldOfView `json:"fieldOfView,omitempty"`
+}
+
+// FieldOfView determines the extent of the area covered by a gateway's Direction mode.
+type FieldOfView uint8
+
+const (
+	// FieldOfViewAuto lets the gateway select its field of view.
+	FieldOfViewAuto = FieldOfView(iota)
+	FieldOfViewWide
+	FieldOfViewNarrow
+)
+
+var (
+	fieldOfViewStrs = [...][]byte{
+		FieldOfViewAuto:   []byte("Auto"),
+		FieldOfViewWide:   []byte("Wide"),
+		FieldOfViewNarrow: []byte("Narrow"),
+	}
+)
+
+func (f FieldOfView) MarshalText() ([]byte, error) {
+	if int(f) >= len(fieldOfViewStrs) {
+		return nil, fmt.Errorf("unknown FieldOfView: %v", f)
+	}
+	return fieldOfViewStrs[f], nil
+}
+
+func (f *FieldOfView) UnmarshalText(text []byte) error {
+	for i := range fieldOfViewStrs {
+		if bytes.Equal(fieldOfViewStrs[i], text) {
+			*f = FieldOfView(i) // #nosec G115
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown FieldOfView: %q", string(text))
+}
+
+// ImpinjReportType indicates why a gateway sent a location or direction report.
+type ImpinjReportType uint8
+
+const (
+	// ImpinjEntryReport is sent when the gateway starts tracking a tag.
+	ImpinjEntryReport = ImpinjReportType(0)
+	// ImpinjUpdateReport is sent periodically while the gateway tracks a tag.
+	ImpinjUpdateReport = ImpinjReportType(1)
+	// ImpinjExitReport is sent when the gateway stops tracking a tag,
+	// because it went unread for the TagAgeInterval.
+	ImpinjExitReport = ImpinjReportType(2)
+)
+
+// ImpinjTagInformation is the content of an ImpinjExtendedTagInformation parameter,
+// which gateways report in place of TagReportData in their Location and Direction modes.
+type ImpinjTagInformation struct {
+	EPC       []byte
+	Location  *ImpinjLocationReport
+	Direction *ImpinjDirectionReport
+}
+
+// ImpinjLocationReport is a tag's position, as estimated by an xArray gateway.
+type ImpinjLocationReport struct {
+	Type ImpinjReportType
+	// LastSeenUTC is when the gateway last read the tag, in microseconds since the Unix Epoch.
+	LastSeenUTC uint64
+	// X and Y are the tag's coordinates, in centimeters.
+	X, Y int32
+}
+
+// ImpinjDirectionReport is the travel of a tag through a gateway's sectors.
+type ImpinjDirectionReport struct {
+	Type ImpinjReportType
+	// FirstSeenSector and LastSeenSector are the sectors
+	// in which the gateway first and last read the tag;
+	// the tag travelled from the former to the latter.
+	FirstSeenSector uint8
+	LastSeenSector  uint8
+	// FirstSeenUTC and LastSeenUTC are in microseconds since the Unix Epoch.
+	FirstSeenUTC uint64
+	LastSeenUTC  uint64
+}
+
+// LastSeenUTC returns the most recent time any of the reports says the gateway read the tag,
+// in microseconds since the Unix Epoch.
+func (ti *ImpinjTagInformation) LastSeenUTC() uint64 {
+	var lastSeen uint64
+	if ti.Location != nil {
+		lastSeen = ti.Location.LastSeenUTC
+	}
+	if ti.Direction != nil {
+		lastSeen = max(lastSeen, ti.Direction.LastSeenUTC)
+	}
+	return lastSeen
+}
+
+// ExtractImpinjTagInformation returns the tag information in the report's
+// ImpinjExtendedTagInformation parameters.
+// It skips those it can't parse or that don't identify a tag.
+func (r *ROAccessReport) ExtractImpinjTagInformation() []ImpinjTagInformation {
+	var infos []ImpinjTagInformation
+	for _, c := range r.Custom {
+		if !c.Is(PENImpinj, ImpinjExtendedTagInformation) {
+			continue
+		}
+
+		if ti, ok := parseImpinjTagInformation(c.Data); ok {
+			infos = append(infos, ti)
+		}
+	}
+	return infos
+}
+
+// paramEPCData and paramCustom are the LLRP parameter types
+// of EPCData and Custom parameters.
+const (
+	paramEPCData = 241
+	paramCustom  = 1023
+)
+
+// parseImpinjTagInformation parses the data of an ImpinjExtendedTagInformation parameter.
+func parseImpinjTagInformation(data []byte) (ti ImpinjTagInformation, ok bool) {
+	for len(data) != 0 {
+		var typ uint16
+		var body []byte
+		typ, body, data, ok = nextParam(data)
+		if !ok {
+			return ti, false
+		}
+
+		switch typ {
+		case paramEPCData:
+			if len(body) < 2 {
+				return ti, false
+			}
+			nBytes := (int(binary.BigEndian.Uint16(body)) + 7) / 8
+			if len(body) < 2+nBytes {
+				return ti, false
+			}
+			ti.EPC = body[2 : 2+nBytes]
+
+		case paramCustom:
+			if len(body) < 8 || VendorPEN(binary.BigEndian.Uint32(body)) != PENImpinj {
+				continue
+			}
+
+			switch sub, fields := binary.BigEndian.Uint32(body[4:]), body[8:]; sub {
+			case ImpinjLocationReportData:
+				// LastSeenTimestampUTC (u64), LocXCentimeters (s32),
+				// LocYCentimeters (s32), Type (u8), then optional sub-parameters
+				if len(fields) < 17 {
+					return ti, false
+				}
+				ti.Location = &ImpinjLocationReport{
+					LastSeenUTC: binary.BigEndian.Uint64(fields),
+					X:           int32(binary.BigEndian.Uint32(fields[8:])),  // #nosec G115
+					Y:           int32(binary.BigEndian.Uint32(fields[12:])), // #nosec G115
+					Type:        ImpinjReportType(fields[16]),
+				}
+
+			case ImpinjDirectionReportData:
+				// Type (u8), TagPopulationStatus (u8),
+				// FirstSeenSectorID (u8), FirstSeenTimestampUTC (u64),
+				// LastSeenSectorID (u8), LastSeenTimestampUTC (u64),
+				// then optional sub-parameters
+				if len(fields) < 20 {
+					return ti, false
+				}
+				ti.Direction = &ImpinjDirectionReport{
+					Type:            ImpinjReportType(fields[0]),
+					FirstSeenSector: fields[2],
+					FirstSeenUTC:    binary.BigEndian.Uint64(fields[3:]),
+					LastSeenSector:  fields[11],
+					LastSeenUTC:     binary.BigEndian.Uint64(fields[12:]),
+				}
+			}
+		}
+	}
+
+	return ti, len(ti.EPC) != 0
+}
+
+// nextParam splits the first TLV parameter from the data,
+// returning its type, its body (the data following its header), and the remaining data.
+func nextParam(data []byte) (typ uint16, body, rest []byte, ok bool) {
+	if len(data) < 4 || data[0]&0x80 != 0 { // TV parameters can't be split without knowing their type
+		return 0, nil, nil, false
+	}
+
+	typ = binary.BigEndian.Uint16(data) & 0x3FF
+	length := int(binary.BigEndian.Uint16(data[2:]))
+	if length < 4 || length > len(data) {
+		return 0, nil, nil, false
+	}
+	return typ, data[4:length], data[length:], true
+}
+
+// impinjParam returns the encoding of an Impinj Custom parameter
+// for use as a sub-parameter in the data of another one.
+func impinjParam(subtype ImpinjParamSubtype, fields ...[]byte) []byte {
+	length := 12 // the header: type, length, vendor, and subtype
+	for _, f := range fields {
+		length += len(f)
+	}
+
+	p := make([]byte, 0, length)
+	p = binary.BigEndian.AppendUint16(p, paramCustom)
+	p = binary.BigEndian.AppendUint16(p, uint16(length)) // #nosec G115
+	p = binary.BigEndian.AppendUint32(p, uint32(PENImpinj))
+	p = binary.BigEndian.AppendUint32(p, subtype)
+	for _, f := range fields {
+		p = append(p, f...)
+	}
+	return p
+}
+
+// Default gateway intervals, in seconds, used for those a Behavior leaves at 0.
+const (
+	defaultComputeWindow  = 10
+	defaultTagAgeInterval = 20
+	defaultUpdateInterval = 5
+)
+
+// orDefault returns v, or def if v is 0.
+func orDefault(v, def uint16) uint16 {
+	if v == 0 {
+		return def
+	}
+	return v
+}
+
+// gatewayReportFlags enables a gateway's entry, update, and exit reports,
+// but not its diagnostic reports.
+//
+// The flags are EnableUpdateReport, EnableEntryReport, EnableExitReport,
+// and EnableDiagnosticReport, from the most significant bit, followed by 4 reserved bits.
+const gatewayReportFlags = 0b1110_0000
+
+// placement returns the ImpinjPlacementConfiguration parameter for the gateway.
+func (l *ImpinjLocation) placement() Custom {
+	data := binary.BigEndian.AppendUint16(nil, l.Height)
+	data = binary.BigEndian.AppendUint32(data, uint32(l.FacilityX))   // #nosec G115
+	data = binary.BigEndian.AppendUint32(data, uint32(l.FacilityY))   // #nosec G115
+	data = binary.BigEndian.AppendUint16(data, uint16(l.Orientation)) // #nosec G115
+	return Custom{
+		VendorID: uint32(PENImpinj),
+		Subtype:  ImpinjPlacementConfiguration,
+		Data:     data,
+	}
+}
+
+// liSpec returns the ImpinjLISpec that runs a gateway in Location mode.
+func (l *ImpinjLocation) liSpec(mode UHFC1G2RFModeTableEntry, session uint8) Custom {
+	var config []byte
+	config = binary.BigEndian.AppendUint16(config, orDefault(l.ComputeWindow, defaultComputeWindow))
+	config = binary.BigEndian.AppendUint16(config, orDefault(l.TagAgeInterval, defaultTagAgeInterval))
+	config = binary.BigEndian.AppendUint16(config, orDefault(l.UpdateInterval, defaultUpdateInterval))
+
+	// ModeIndex (u16), then Session (u2) and 6 reserved bits
+	c1g2Config := binary.BigEndian.AppendUint16(nil, uint16(mode.ModeID)) // #nosec G115
+	c1g2Config = append(c1g2Config, session<<6)
+
+	return Custom{
+		VendorID: uint32(PENImpinj),
+		Subtype:  ImpinjLISpec,
+		Data: bytes.Join([][]byte{
+			impinjParam(ImpinjLocationConfig, config),
+			impinjParam(ImpinjC1G2LocationConfig, c1g2Config),
+			impinjParam(ImpinjLocationReporting, []byte{gatewayReportFlags}),
+		}, nil),
+	}
+}
+
+// diSpec returns the ImpinjDISpec that runs a gateway in Direction mode.
+func (dir *ImpinjDirection) diSpec(scan ScanType) Custom {
+	sectors := binary.BigEndian.AppendUint16(nil, uint16(len(dir.Sectors))) // #nosec G115
+	for _, s := range dir.Sectors {
+		sectors = binary.BigEndian.AppendUint16(sectors, uint16(s))
+	}
+
+	var config []byte
+	config = binary.BigEndian.AppendUint16(config, orDefault(dir.TagAgeInterval, defaultTagAgeInterval))
+	config = binary.BigEndian.AppendUint16(config, orDefault(dir.UpdateInterval, defaultUpdateInterval))
+	config = append(config, uint8(dir.FieldOfView))
+
+	// The gateway's RF mode is either HighSensitivity (0) or HighPerformance (1).
+	rfMode := uint16(0)
+	if scan == ScanFast {
+		rfMode = 1
+	}
+
+	return Custom{
+		VendorID: uint32(PENImpinj),
+		Subtype:  ImpinjDISpec,
+		Data: bytes.Join([][]byte{
+			impinjParam(ImpinjDirectionSectors, sectors),
+			impinjParam(ImpinjDirectionConfig, config),
+			impinjParam(ImpinjC1G2DirectionConfig, binary.BigEndian.AppendUint16(nil, rfMode)),
+			// the flags are followed by the DiagnosticReportLevel, which is unused
+			impinjParam(ImpinjDirectionReporting, []byte{gatewayReportFlags, 0}),
+		}, nil),
+	}
+}
+
+// supportsLocation returns true if the model is an xArray,
+// which can run in Location mode.
+func (m ImpinjModel) supportsLocation() bool {
+	return m == XArray || m == XArrayWM || m == XArrayEAP
+}
+
+// supportsDirection returns true if the model is an xArray or xSpan,
+// which can run in Direction mode.
+func (m ImpinjModel) supportsDirection() bool {
+	return m.supportsLocation() || m == XSpan
+}
+
+// newGatewayROSpec returns an ROSpec that runs the gateway
+// in the Location or Direction mode set by the Behavior's ImpinjOptions,
+// in place of an AISpec.
+//
+// Those modes manage the gateway's antennas and tag populations themselves,
+// so Behaviors that use them can't set antennas, filters, or memory reads.
+func (d *ImpinjDevice) newGatewayROSpec(b Behavior, mode UHFC1G2RFModeTableEntry) (*ROSpec, error) {
+	opts := b.ImpinjOptions
+	if opts.Location != nil && opts.Direction != nil {
+		return nil, fmt.Errorf("behavior uses both Location and Direction modes: %w", ErrUnsatisfiable)
+	}
+
+	if len(b.Antennas) != 0 || len(b.Sequence) != 0 || len(b.Filters) != 0 ||
+		len(b.MemoryReads) != 0 || opts.OptimizedRead {
+		return nil, fmt.Errorf("behavior uses antennas, filters, or memory reads, "+
+			"which gateways don't support in Location or Direction mode: %w", ErrUnsatisfiable)
+	}
+
+	var spec Custom
+	if opts.Location != nil {
+		if !d.model.supportsLocation() {
+			return nil, fmt.Errorf("behavior uses Location mode, "+
+				"but the Reader's model (%v) doesn't support it: %w", d.model, ErrUnsatisfiable)
+		}
+
+		session := uint8(2)
+		switch b.ScanType {
+		case ScanFast:
+			session = 0
+		case ScanNormal:
+			session = 1
+		}
+		spec = opts.Location.liSpec(mode, session)
+	} else {
+		if !d.model.supportsDirection() {
+			return nil, fmt.Errorf("behavior uses Direction mode, "+
+				"but the Reader's model (%v) doesn't support it: %w", d.model, ErrUnsatisfiable)
+		}
+
+		if len(opts.Direction.Sectors) < 2 {
+			return nil, fmt.Errorf("behavior's Direction mode must have at least 2 sectors, "+
+				"but it has %d: %w", len(opts.Direction.Sectors), ErrUnsatisfiable)
+		}
+		if int(opts.Direction.FieldOfView) >= len(fieldOfViewStrs) {
+			return nil, fmt.Errorf("behavior's Direction mode has unknown field of view %d: %w",
+				opts.Direction.FieldOfView, ErrUnsatisfiable)
+		}
+		spec = opts.Direction.diSpec(b.ScanType)
+	}
+
+	return &ROSpec{
+		ROSpecID:       1, // May be overridden, but better to ensure it's not 0.
+		ROBoundarySpec: b.Boundary(),
+		Custom:         []Custom{spec},
+	}, nil
+}
@@ -0,0 +1,176 @@
+//
+// Copyright (C) 2026 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package llrp
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newGateway(t *testing.T, model ImpinjModel) *ImpinjDevice {
+	t.Helper()
+	caps := newImpinjCaps(t)
+	caps.GeneralDeviceCapabilities.Model = uint32(model)
+	d, err := NewImpinjDevice(caps)
+	require.NoError(t, err)
+	return d
+}
+
+func TestImpinjDevice_location(t *testing.T) {
+	b := Behavior{
+		ScanType: ScanDeep,
+		Power:    PowerTarget{Max: 3000},
+		ImpinjOptions: &ImpinjOptions{Location: &ImpinjLocation{
+			UpdateInterval: 2,
+			Height:         300,
+			FacilityX:      -150,
+			FacilityY:      400,
+			Orientation:    90,
+		}},
+	}
+
+	xArray := newGateway(t, XArray)
+	spec, err := xArray.NewROSpec(b, Environment{})
+	require.NoError(t, err)
+	assert.Empty(t, spec.AISpecs)
+	require.Len(t, spec.Custom, 1)
+	assert.True(t, spec.Custom[0].Is(PENImpinj, ImpinjLISpec))
+
+	data := spec.Custom[0].Data
+	var subtypes []uint32
+	for len(data) != 0 {
+		typ, body, rest, ok := nextParam(data)
+		require.True(t, ok)
+		assert.Equal(t, uint16(paramCustom), typ)
+		subtypes = append(subtypes, binary.BigEndian.Uint32(body[4:]))
+		if binary.BigEndian.Uint32(body[4:]) == ImpinjLocationConfig {
+			assert.Equal(t, []byte{0, 10, 0, 20, 0, 2}, body[8:])
+		}
+		data = rest
+	}
+	assert.Equal(t, []uint32{ImpinjLocationConfig, ImpinjC1G2LocationConfig, ImpinjLocationReporting}, subtypes)
+
+	conf, err := xArray.NewReportConfig(b)
+	require.NoError(t, err)
+	require.Len(t, conf.Custom, 1)
+	assert.True(t, conf.Custom[0].Is(PENImpinj, ImpinjPlacementConfiguration))
+	assert.Equal(t, []byte{
+		0x01, 0x2C,
+		0xFF, 0xFF, 0xFF, 0x6A,
+		0x00, 0x00, 0x01, 0x90,
+		0x00, 0x5A,
+	}, conf.Custom[0].Data)
+
+	for _, model := range []ImpinjModel{XSpan, SpeedwayR420} {
+		_, err = newGateway(t, model).NewROSpec(b, Environment{})
+		assert.ErrorIs(t, err, ErrUnsatisfiable, "%v shouldn't support Location mode", model)
+	}
+
+	b.MemoryReads = []MemoryRead{{Name: "tid", MemoryBank: MemoryBankTID, WordCount: 2}}
+	_, err = xArray.NewROSpec(b, Environment{})
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+}
+
+func TestImpinjDevice_direction(t *testing.T) {
+	b := Behavior{
+		Power: PowerTarget{Max: 3000},
+		ImpinjOptions: &ImpinjOptions{Direction: &ImpinjDirection{
+			Sectors:     []uint8{2, 3},
+			FieldOfView: FieldOfViewNarrow,
+		}},
+	}
+
+	spec, err := newGateway(t, XSpan).NewROSpec(b, Environment{})
+	require.NoError(t, err)
+	require.Len(t, spec.Custom, 1)
+	assert.True(t, spec.Custom[0].Is(PENImpinj, ImpinjDISpec))
+
+	_, body, _, ok := nextParam(spec.Custom[0].Data)
+	require.True(t, ok)
+	assert.Equal(t, ImpinjDirectionSectors, binary.BigEndian.Uint32(body[4:]))
+	assert.Equal(t, []byte{0, 2, 0, 2, 0, 3}, body[8:])
+
+	_, err = newGateway(t, SpeedwayR420).NewROSpec(b, Environment{})
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+
+	xArray := newGateway(t, XArray)
+	b.ImpinjOptions.Direction.Sectors = []uint8{2}
+	_, err = xArray.NewROSpec(b, Environment{})
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+
+	b.ImpinjOptions.Direction.Sectors = []uint8{2, 3}
+	b.ImpinjOptions.Location = &ImpinjLocation{}
+	_, err = xArray.NewROSpec(b, Environment{})
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+
+	var dir ImpinjDirection
+	require.NoError(t, json.Unmarshal([]byte(`{"sectors":[2,3],"fieldOfView":"Wide"}`), &dir))
+	assert.Equal(t, FieldOfViewWide, dir.FieldOfView)
+	assert.Error(t, json.Unmarshal([]byte(`{"fieldOfView":"Tall"}`), &dir))
+}
+
+func TestExtractImpinjTagInformation(t *testing.T) {
+	epc := []byte{0x30, 0x14, 0x36, 0x39, 0xF8, 0x41, 0x91, 0xAD, 0x22, 0x90, 0x02, 0x04}
+	epcData := []byte{0x00, paramEPCData, 0, 18, 0, 96}
+	epcData = append(epcData, epc...)
+
+	var location []byte
+	location = binary.BigEndian.AppendUint64(location, 1_600_000_000_000_000)
+	location = binary.BigEndian.AppendUint32(location, uint32(125))
+	location = binary.BigEndian.AppendUint32(location, 0xFFFFFFCE) // -50
+	location = append(location, uint8(ImpinjUpdateReport))
+
+	direction := []byte{uint8(ImpinjExitReport), 0, 2}
+	direction = binary.BigEndian.AppendUint64(direction, 1_600_000_000_000_000)
+	direction = append(direction, 3)
+	direction = binary.BigEndian.AppendUint64(direction, 1_600_000_002_000_000)
+
+	r := ROAccessReport{Custom: []Custom{
+		{VendorID: uint32(PENImpinj), Subtype: ImpinjPeakRSSI, Data: []byte{0, 1}},
+		{
+			VendorID: uint32(PENImpinj),
+			Subtype:  ImpinjExtendedTagInformation,
+			Data:     append(append([]byte{}, epcData...), impinjParam(ImpinjLocationReportData, location)...),
+		},
+		{
+			VendorID: uint32(PENImpinj),
+			Subtype:  ImpinjExtendedTagInformation,
+			Data:     append(append([]byte{}, epcData...), impinjParam(ImpinjDirectionReportData, direction)...),
+		},
+		{
+			VendorID: uint32(PENImpinj),
+			Subtype:  ImpinjExtendedTagInformation,
+			Data:     epcData[:10], // truncated
+		},
+	}}
+
+	infos := r.ExtractImpinjTagInformation()
+	require.Len(t, infos, 2)
+
+	assert.Equal(t, epc, infos[0].EPC)
+	assert.Nil(t, infos[0].Direction)
+	assert.Equal(t, &ImpinjLocationReport{
+		Type:        ImpinjUpdateReport,
+		LastSeenUTC: 1_600_000_000_000_000,
+		X:           125,
+		Y:           -50,
+	}, infos[0].Location)
+
+	assert.Equal(t, epc, infos[1].EPC)
+	assert.Nil(t, infos[1].Location)
+	assert.Equal(t, &ImpinjDirectionReport{
+		Type:            ImpinjExitReport,
+		FirstSeenSector: 2,
+		FirstSeenUTC:    1_600_000_000_000_000,
+		LastSeenSector:  3,
+		LastSeenUTC:     1_600_000_002_000_000,
+	}, infos[1].Direction)
+	assert.Equal(t, uint64(1_600_000_002_000_000), infos[1].LastSeenUTC())
+}
@@ -21,11 +21,35 @@ type ROGenerator interface {
 	NewROSpec(b Behavior, e Environment) (*ROSpec, error)
 }
 
+// AccessGenerator generates the AccessSpecs needed to achieve a Behavior,
+// or returns an error if it cannot produce them.
+type AccessGenerator interface {
+	NewAccessSpecs(b Behavior) ([]AccessSpec, error)
+}
+
 // ReportProcessor is anything that can accept a list of TagReportData.
 type ReportProcessor interface {
 	ProcessTagReport(tags []TagReportData)
 }
 
+// ReportConfigurer generates the reader configuration that sets
+// the tag report contents a Behavior needs,
+// and records those contents once a Reader accepts it.
+type ReportConfigurer interface {
+	NewReportConfig(b Behavior) (*SetReaderConfig, error)
+	ConfigApplied(conf *SetReaderConfig)
+}
+
+// GPOConfigurer generates the reader configuration that sets a Reader's GPO ports.
+type GPOConfigurer interface {
+	NewGPOConfig(writes []GPOWriteData) (*SetReaderConfig, error)
+}
+
+// GPIConfigurer generates the reader configuration that enables a Reader's GPI ports.
+type GPIConfigurer interface {
+	NewGPIConfig(ports []uint16) (*SetReaderConfig, error)
+}
+
 // TagReader is something which can process TagReportData
 // generated as a result of executing any ROSpec it generates.
 //
@@ -34,7 +58,11 @@ type ReportProcessor interface {
 // is up to (and should be specified by) the particular TagReader implementation.
 type TagReader interface {
 	ROGenerator
+	AccessGenerator
 	ReportProcessor
+	ReportConfigurer
+	GPOConfigurer
+	GPIConfigurer
 }
 
 // A ReaderGroup unites a collection of named TagReader instances
@@ -44,6 +72,11 @@ type ReaderGroup struct {
 	readers  map[string]TagReader
 	env      Environment
 	behavior Behavior
+	// reading is true after StartAll and false after StopAll.
+	reading bool
+	// nearby is the NumNearbyReaders used for the current ROSpecs
+	// when the Environment has AutoNearbyReaders.
+	nearby uint
 }
 
 func NewReaderGroup() *ReaderGroup {
@@ -69,6 +102,18 @@ func (rg *ReaderGroup) Behavior() Behavior {
 	return b
 }
 
+// Environment returns the ReaderGroup's current Environment.
+// If it has AutoNearbyReaders, its NumNearbyReaders is the number of readers in the group.
+func (rg *ReaderGroup) Environment() Environment {
+	rg.mu.RLock()
+	e := rg.env
+	if e.AutoNearbyReaders {
+		e.NumNearbyReaders = uint(len(rg.readers))
+	}
+	rg.mu.RUnlock()
+	return e
+}
+
 // WriteReaders writes to w a JSON-formatted list of readers in this group.
 func (rg *ReaderGroup) WriteReaders(w io.Writer) error {
 	rg.mu.RLock()
@@ -89,10 +134,11 @@ func (rg *ReaderGroup) WriteReaders(w io.Writer) error {
 // this method returns false.
 // Otherwise, it returns true.
 func (rg *ReaderGroup) ProcessTagReport(name string, tags []TagReportData) bool {
+	// Hold the lock while processing so the report contents can't change underneath it.
 	rg.mu.RLock()
-	tr, ok := rg.readers[name]
-	rg.mu.RUnlock()
+	defer rg.mu.RUnlock()
 
+	tr, ok := rg.readers[name]
 	if !ok {
 		return false
 	}
@@ -101,6 +147,117 @@ func (rg *ReaderGroup) ProcessTagReport(name string, tags []TagReportData) bool
 	return true
 }
 
+// ReaderNames returns the names of the readers in this group.
+func (rg *ReaderGroup) ReaderNames() []string {
+	rg.mu.RLock()
+	defer rg.mu.RUnlock()
+
+	names := make([]string, 0, len(rg.readers))
+	for r := range rg.readers {
+		names = append(names, r)
+	}
+	return names
+}
+
+// IsReading returns true if the group was most recently started by StartAll,
+// rather than stopped by StopAll. A ReaderGroup is initially stopped.
+func (rg *ReaderGroup) IsReading() bool {
+	rg.mu.RLock()
+	defer rg.mu.RUnlock()
+	return rg.reading
+}
+
+// HasReader returns true if the ReaderGroup has a TagReader with the given name.
+func (rg *ReaderGroup) HasReader(name string) bool {
+	rg.mu.RLock()
+	_, ok := rg.readers[name]
+	rg.mu.RUnlock()
+	return ok
+}
+
+// ValidateReader returns an error if the named TagReader
+// can't implement the Behavior in the Environment,
+// such as before moving it to a group that uses them.
+// It doesn't change the TagReader or the ReaderGroup.
+func (rg *ReaderGroup) ValidateReader(name string, b Behavior, e Environment) error {
+	rg.mu.RLock()
+	r, ok := rg.readers[name]
+	rg.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("reader %q is not in the group", name)
+	}
+
+	if _, err := r.NewROSpec(b, e); err != nil {
+		return fmt.Errorf("behavior is invalid for %q: %w", name, err)
+	}
+	if _, err := r.NewAccessSpecs(b); err != nil {
+		return fmt.Errorf("behavior is invalid for %q: %w", name, err)
+	}
+	if _, err := r.NewReportConfig(b); err != nil {
+		return fmt.Errorf("behavior is invalid for %q: %w", name, err)
+	}
+	return nil
+}
+
+// AccessSpecIDs returns the IDs of the AccessSpecs the named TagReader uses
+// for the group's Behavior, which are none if it has no MemoryReads
+// or the TagReader performs them some other way, such as with Impinj's optimized read.
+func (rg *ReaderGroup) AccessSpecIDs(name string) ([]uint32, error) {
+	rg.mu.RLock()
+	r, ok := rg.readers[name]
+	b := rg.behavior
+	rg.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("reader %q is not in the group", name)
+	}
+
+	specs, err := r.NewAccessSpecs(b)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uint32, len(specs))
+	for i := range specs {
+		ids[i] = specs[i].AccessSpecID
+	}
+	return ids, nil
+}
+
+// WriteGPO uses the DSClient to set the named Reader's GPO ports.
+// It returns an error wrapping ErrInvalidGPO if the Reader doesn't have them.
+func (rg *ReaderGroup) WriteGPO(ds DSClient, name string, writes []GPOWriteData) error {
+	rg.mu.RLock()
+	r, ok := rg.readers[name]
+	rg.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("reader %q is not in the group", name)
+	}
+
+	conf, err := r.NewGPOConfig(writes)
+	if err != nil {
+		return err
+	}
+	return ds.SetConfig(name, conf)
+}
+
+// EnableGPIs uses the DSClient to enable the named Reader's GPI ports
+// and its notifications of their changes.
+// It returns an error wrapping ErrInvalidGPI if the Reader doesn't have them.
+func (rg *ReaderGroup) EnableGPIs(ds DSClient, name string, ports []uint16) error {
+	rg.mu.RLock()
+	r, ok := rg.readers[name]
+	rg.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("reader %q is not in the group", name)
+	}
+
+	conf, err := r.NewGPIConfig(ports)
+	if err != nil {
+		return err
+	}
+	return ds.SetConfig(name, conf)
+}
+
 // RemoveReader removes the named Reader from the ReaderGroup, if present.
 // If no Reader with that name is in the ReaderGroup, nothing happens.
 func (rg *ReaderGroup) RemoveReader(name string) {
@@ -114,7 +271,8 @@ func (rg *ReaderGroup) RemoveReader(name string) {
 // First, it uses the name to request a TagReader from the DSClient,
 // then it uses that TagReader to generate an ROSpec
 // based on the ReaderGroup's Behavior and Environment.
-// Finally, it uses the DSClient to replace that device's ROSpec with the new one.
+// Finally, it uses the DSClient to replace that device's ROSpec with the new one,
+// along with any AccessSpecs needed for the Behavior's MemoryReads.
 //
 // If these steps all succeed, the ReaderGroup accepts the TagReader,
 // possibly replacing a previously-held TagReader with the same name.
@@ -134,6 +292,12 @@ func (rg *ReaderGroup) AddReader(ds DSClient, name string) error {
 	rg.mu.RLock()
 	env := rg.env
 	b := rg.behavior
+	if env.AutoNearbyReaders {
+		env.NumNearbyReaders = uint(len(rg.readers))
+		if _, ok := rg.readers[name]; !ok {
+			env.NumNearbyReaders++
+		}
+	}
 	rg.mu.RUnlock()
 
 	s, err := r.NewROSpec(b, env)
@@ -141,20 +305,65 @@ func (rg *ReaderGroup) AddReader(ds DSClient, name string) error {
 		return err
 	}
 
+	access, err := r.NewAccessSpecs(b)
+	if err != nil {
+		return err
+	}
+
+	// The Reader was just configured with the default report contents.
+	if b.customReport() {
+		conf, err := r.NewReportConfig(b)
+		if err != nil {
+			return err
+		}
+
+		if err := ds.SetConfig(name, conf); err != nil {
+			return err
+		}
+		r.ConfigApplied(conf)
+	}
+
 	s.ROSpecID = defaultROSpecID
 	if err := replaceRO(ds, name, s); err != nil {
 		return err
 	}
 
+	// The Reader's config was reset to factory defaults when it was created,
+	// so only bother replacing AccessSpecs if we need some.
+	if len(access) != 0 {
+		if err := replaceAccess(ds, name, access); err != nil {
+			return err
+		}
+	}
+
 	rg.mu.Lock()
 	rg.readers[name] = r
 	rg.mu.Unlock()
 
-	ds.lc.Info(fmt.Sprintf("Successfully added device %s to default group.", name))
+	ds.lc.Info(fmt.Sprintf("Successfully added device %s to reader group.", name))
+
+	// The other readers' ROSpecs may need to account for the new one.
+	if err := rg.UpdateNearbyReaders(ds); err != nil {
+		ds.lc.Warn("Failed to update ROSpecs for the number of nearby readers.", "error", err.Error())
+	}
 
 	return nil
 }
 
+// UpdateNearbyReaders regenerates the ROSpecs of the TagReaders in the ReaderGroup
+// if its Environment has AutoNearbyReaders
+// and the number of readers changed since they were generated,
+// such as after RemoveReader.
+func (rg *ReaderGroup) UpdateNearbyReaders(ds DSClient) error {
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+
+	if !rg.env.AutoNearbyReaders || rg.nearby == uint(len(rg.readers)) {
+		return nil
+	}
+	return rg.apply(ds, rg.behavior, rg.env)
+}
+
 // replaceRO deletes any ROSpec on the named device, then adds the given ROSpec.
 // This won't try to Add the ROSpec unless the delete is successful,
 // but it's possible the delete succeeds but the add fails.
@@ -166,6 +375,27 @@ func replaceRO(ds DSClient, name string, spec *ROSpec) error {
 	return ds.AddROSpec(name, spec)
 }
 
+// replaceAccess deletes any AccessSpecs on the named device,
+// then adds and enables each of the given AccessSpecs.
+// It stops at the first error, so some of the AccessSpecs may not be added.
+func replaceAccess(ds DSClient, name string, specs []AccessSpec) error {
+	if err := ds.DeleteAllAccessSpecs(name); err != nil {
+		return err
+	}
+
+	for i := range specs {
+		if err := ds.AddAccessSpec(name, &specs[i]); err != nil {
+			return err
+		}
+
+		if err := ds.EnableAccessSpec(name, specs[i].AccessSpecID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // SetBehavior changes the ReaderGroup's Behavior.
 //
 // The new Behavior must be valid for every TagReader in the ReaderGroup.
@@ -181,7 +411,9 @@ func replaceRO(ds DSClient, name string, spec *ROSpec) error {
 // and will return it from calls to ReaderGroup.Behavior().
 //
 // Before this method returns, assuming the Behavior is accepted,
-// it concurrently sends each newly generated ROSpec to the appropriate TagReader.
+// it concurrently sends each newly generated ROSpec to the appropriate TagReader,
+// preceded by its report configuration, if either Behavior changes it from the defaults,
+// and followed by its AccessSpecs, if either the old or new Behavior has MemoryReads.
 // Any errors returned by this step are collected into a MultiErr
 // which is returned after the last update call completes.
 // A failure to set one TagReader's ROSpec does not have an impact on others.
@@ -193,32 +425,94 @@ func replaceRO(ds DSClient, name string, spec *ROSpec) error {
 func (rg *ReaderGroup) SetBehavior(ds DSClient, b Behavior) error {
 	rg.mu.Lock()
 	defer rg.mu.Unlock()
+	return rg.apply(ds, b, rg.env)
+}
+
+// SetEnvironment changes the ReaderGroup's Environment.
+//
+// Like SetBehavior, it generates new ROSpecs for each TagReader in the ReaderGroup,
+// and rejects the Environment if any TagReader can't use it
+// to implement the current Behavior.
+// Otherwise, it accepts the new Environment,
+// and sends the new ROSpecs to the TagReaders,
+// returning a MultiErr if any of them fail.
+func (rg *ReaderGroup) SetEnvironment(ds DSClient, e Environment) error {
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+	return rg.apply(ds, rg.behavior, e)
+}
+
+// apply generates ROSpecs and AccessSpecs for the Behavior and Environment
+// for every TagReader in the ReaderGroup, and if they all succeed,
+// accepts them and replaces each TagReader's specs.
+// The caller must hold the write lock.
+func (rg *ReaderGroup) apply(ds DSClient, b Behavior, e Environment) error {
+	effective := e
+	if e.AutoNearbyReaders {
+		effective.NumNearbyReaders = uint(len(rg.readers))
+	}
 
 	specs := map[string]*ROSpec{}
+	accessSpecs := map[string][]AccessSpec{}
+	configs := map[string]*SetReaderConfig{}
 	for name, r := range rg.readers {
-		s, err := r.NewROSpec(b, rg.env)
+		s, err := r.NewROSpec(b, effective)
+		if err != nil {
+			return fmt.Errorf("new behavior is invalid for %q: %w", name, err)
+		}
+
+		access, err := r.NewAccessSpecs(b)
+		if err != nil {
+			return fmt.Errorf("new behavior is invalid for %q: %w", name, err)
+		}
+
+		conf, err := r.NewReportConfig(b)
 		if err != nil {
 			return fmt.Errorf("new behavior is invalid for %q: %w", name, err)
 		}
 
 		s.ROSpecID = defaultROSpecID
 		specs[name] = s
+		accessSpecs[name] = access
+		configs[name] = conf
 	}
 
+	// AccessSpecs only need replacing if either Behavior reads tag memory,
+	// and the report contents only if either changes them from the defaults.
+	updateAccess := len(rg.behavior.MemoryReads) != 0 || len(b.MemoryReads) != 0
+	updateReport := rg.behavior.customReport() || b.customReport()
+
 	// The behavior is valid for all members of the group.
 	rg.behavior = b
+	rg.env = e
+	rg.nearby = effective.NumNearbyReaders
 
-	// Replace each reader's ROSpec.
+	// Replace each reader's ROSpec and AccessSpecs.
 	errs := make(chan error, len(specs))
 	wg := sync.WaitGroup{}
 	wg.Add(len(specs))
 	for d, s := range specs {
-		go func(name string, s *ROSpec) {
+		go func(name string, s *ROSpec, access []AccessSpec) {
 			defer wg.Done()
+			if updateReport {
+				if err := ds.SetConfig(name, configs[name]); err != nil {
+					errs <- fmt.Errorf("failed to set report contents for %q: %v", name, err)
+					return
+				}
+				rg.readers[name].ConfigApplied(configs[name])
+			}
+
 			if err := replaceRO(ds, name, s); err != nil {
 				errs <- fmt.Errorf("failed to replace ROSpec for %q: %v", name, err)
+				return
 			}
-		}(d, s)
+
+			if updateAccess {
+				if err := replaceAccess(ds, name, access); err != nil {
+					errs <- fmt.Errorf("failed to replace AccessSpecs for %q: %v", name, err)
+				}
+			}
+		}(d, s, accessSpecs[d])
 	}
 
 	// Wait for the replace calls to complete, then collect any errors.
@@ -253,21 +547,16 @@ func (me MultiErr) Error() string {
 }
 
 // StartAll uses the DSClient to start all TagReaders in the ReaderGroup.
+// Afterwards, the group is reading, even if some TagReaders failed to start.
 func (rg *ReaderGroup) StartAll(ds DSClient) error {
-	rg.mu.RLock()
-	defer rg.mu.RUnlock()
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+
+	rg.reading = true
 
 	var errs []error
 	for name := range rg.readers {
-		if err := ds.EnableROSpec(name, 1); err != nil {
-			errs = append(errs, err)
-		}
-
-		if rg.behavior.StartTrigger().Trigger == ROStartTriggerNone {
-			if err := ds.StartROSpec(name, 1); err != nil {
-				errs = append(errs, err)
-			}
-		}
+		errs = append(errs, rg.start(ds, name)...)
 	}
 
 	if errs != nil {
@@ -276,14 +565,59 @@ func (rg *ReaderGroup) StartAll(ds DSClient) error {
 	return nil
 }
 
-// StopAll uses the DSClient to stop all TagReaders in the ReaderGroup.
-func (rg *ReaderGroup) StopAll(ds DSClient) error {
+// StartReader uses the DSClient to start the named TagReader
+// if the ReaderGroup is reading, such as after it's moved into the group.
+// If the group isn't reading, it does nothing.
+func (rg *ReaderGroup) StartReader(ds DSClient, name string) error {
 	rg.mu.RLock()
 	defer rg.mu.RUnlock()
 
+	if _, ok := rg.readers[name]; !ok {
+		return fmt.Errorf("reader %q is not in the group", name)
+	}
+	if !rg.reading {
+		return nil
+	}
+
+	if errs := rg.start(ds, name); errs != nil {
+		return MultiErr(errs)
+	}
+	return nil
+}
+
+// start enables the named Reader's ROSpec,
+// and starts it if the Behavior doesn't have a start trigger.
+// The caller must hold the lock.
+func (rg *ReaderGroup) start(ds DSClient, name string) (errs []error) {
+	if err := ds.EnableROSpec(name, 1); err != nil {
+		errs = append(errs, err)
+	}
+
+	// Once enabled, Readers start ROSpecs with Immediate, GPI, or Periodic triggers on their own.
+	if rg.behavior.StartTrigger().Trigger == ROStartTriggerNone {
+		if err := ds.StartROSpec(name, 1); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// StopAll uses the DSClient to stop all TagReaders in the ReaderGroup.
+// Afterwards, the group is stopped, even if some TagReaders failed to stop.
+func (rg *ReaderGroup) StopAll(ds DSClient) error {
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+
+	rg.reading = false
+
+	// Disabling an ROSpec also stops it if it's active,
+	// so only those started manually need an explicit stop;
+	// for the others, stopping fails if they're between runs.
+	manual := rg.behavior.StartTrigger().Trigger == ROStartTriggerNone
+
 	var errs []error
 	for name := range rg.readers {
-		if rg.behavior.StartTrigger().Trigger == ROStartTriggerNone {
+		if manual {
 			if err := ds.StopROSpec(name, 1); err != nil {
 				errs = append(errs, err)
 			}
@@ -257,6 +257,12 @@ func TestAddReader(t *testing.T) {
 			args:    args{ds: dsClient, name: "test-reader"},
 			wantErr: ErrUnsatisfiable,
 		},
+		{
+			name:    "OK - add reader with memory reads",
+			fields:  fields{readers: map[string]TagReader{}, env: Environment{}, behavior: Behavior{Power: PowerTarget{Max: 30000}, MemoryReads: []MemoryRead{{Name: "tid", MemoryBank: MemoryBankTID, WordCount: 6}}}},
+			args:    args{ds: dsClient, name: "test-reader"},
+			wantErr: nil,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -344,6 +350,18 @@ func TestSetBehavior(t *testing.T) {
 			args:    args{ds: dsClient, b: Behavior{Power: PowerTarget{-1}, GPITrigger: &GPITrigger{Port: 0, Event: false, Timeout: ImpinjSearchMode}}},
 			wantErr: ErrUnsatisfiable,
 		},
+		{
+			name:    "OK - add memory reads",
+			fields:  fields{readers: map[string]TagReader{"test": rg.readers["test"]}, env: Environment{}, behavior: Behavior{Power: PowerTarget{3000}}},
+			args:    args{ds: dsClient, b: Behavior{Power: PowerTarget{3000}, MemoryReads: []MemoryRead{{Name: "tid", MemoryBank: MemoryBankTID, WordCount: 6}}}},
+			wantErr: nil,
+		},
+		{
+			name:    "OK - attempt change to invalid memory reads",
+			fields:  fields{readers: map[string]TagReader{"test": rg.readers["test"]}, env: Environment{}, behavior: Behavior{Power: PowerTarget{3000}}},
+			args:    args{ds: dsClient, b: Behavior{Power: PowerTarget{3000}, MemoryReads: []MemoryRead{{MemoryBank: MemoryBankTID}}}},
+			wantErr: ErrUnsatisfiable,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -358,6 +376,39 @@ func TestSetBehavior(t *testing.T) {
 	}
 }
 
+func TestSetEnvironment(t *testing.T) {
+	rg, dsClient, tsClose := addReaderHelper(t)
+	defer tsClose()
+
+	env := Environment{NumNearbyReaders: 3, PopulationSize: 100}
+	require.NoError(t, rg.SetEnvironment(dsClient, env))
+	assert.Equal(t, env, rg.Environment())
+
+	// the environment is rejected if the current behavior can't be implemented with it
+	rg.behavior.GPITrigger = &GPITrigger{Port: 0}
+	err := rg.SetEnvironment(dsClient, Environment{})
+	assert.ErrorIs(t, err, ErrUnsatisfiable)
+	assert.Equal(t, env, rg.Environment())
+}
+
+func TestAutoNearbyReaders(t *testing.T) {
+	rg, dsClient, tsClose := addReaderHelper(t)
+	defer tsClose()
+
+	require.NoError(t, rg.SetEnvironment(dsClient, Environment{NumNearbyReaders: 5, AutoNearbyReaders: true}))
+	assert.Equal(t, uint(1), rg.Environment().NumNearbyReaders)
+	assert.Equal(t, uint(1), rg.nearby)
+
+	require.NoError(t, rg.AddReader(dsClient, "other"))
+	assert.Equal(t, uint(2), rg.Environment().NumNearbyReaders)
+	assert.Equal(t, uint(2), rg.nearby)
+
+	rg.RemoveReader("other")
+	assert.Equal(t, uint(2), rg.nearby, "ROSpecs aren't regenerated until requested")
+	require.NoError(t, rg.UpdateNearbyReaders(dsClient))
+	assert.Equal(t, uint(1), rg.nearby)
+}
+
 func TestError(t *testing.T) {
 	tests := []struct {
 		name string
@@ -428,10 +479,90 @@ func TestStartAll(t *testing.T) {
 			}
 			err := rg.StartAll(tt.args.ds)
 			assert.ErrorIs(t, err, tt.wantErr)
+			assert.True(t, rg.IsReading())
+
+			assert.NoError(t, rg.StopAll(tt.args.ds))
+			assert.False(t, rg.IsReading())
 		})
 	}
 }
 
+func TestWriteGPO(t *testing.T) {
+	rg, dsClient, tsClose := addReaderHelper(t)
+	defer tsClose()
+
+	assert.NoError(t, rg.WriteGPO(dsClient, "test", []GPOWriteData{{Port: 1, Data: true}, {Port: 4}}))
+	assert.ErrorIs(t, rg.WriteGPO(dsClient, "test", []GPOWriteData{{Port: 5, Data: true}}), ErrInvalidGPO)
+	assert.ErrorIs(t, rg.WriteGPO(dsClient, "test", []GPOWriteData{{Port: 0}}), ErrInvalidGPO)
+	assert.ErrorIs(t, rg.WriteGPO(dsClient, "test", nil), ErrInvalidGPO)
+	assert.Error(t, rg.WriteGPO(dsClient, "unknown", []GPOWriteData{{Port: 1}}))
+}
+
+func TestEnableGPIs(t *testing.T) {
+	rg, dsClient, tsClose := addReaderHelper(t)
+	defer tsClose()
+
+	assert.NoError(t, rg.EnableGPIs(dsClient, "test", []uint16{1, 4}))
+	assert.ErrorIs(t, rg.EnableGPIs(dsClient, "test", []uint16{5}), ErrInvalidGPI)
+	assert.ErrorIs(t, rg.EnableGPIs(dsClient, "test", nil), ErrInvalidGPI)
+	assert.Error(t, rg.EnableGPIs(dsClient, "unknown", []uint16{1}))
+}
+
+func TestStartReader(t *testing.T) {
+	rg, dsClient, tsClose := addReaderHelper(t)
+	defer tsClose()
+
+	assert.NoError(t, rg.StartReader(dsClient, "test"), "a stopped group doesn't start its readers")
+	assert.Error(t, rg.StartReader(dsClient, "unknown"))
+
+	require.NoError(t, rg.StartAll(dsClient))
+	assert.NoError(t, rg.StartReader(dsClient, "test"))
+}
+
+func TestValidateReader(t *testing.T) {
+	rg, _, tsClose := addReaderHelper(t)
+	defer tsClose()
+
+	assert.NoError(t, rg.ValidateReader("test", rg.Behavior(), rg.Environment()))
+	assert.ErrorIs(t, rg.ValidateReader("test", Behavior{}, rg.Environment()), ErrUnsatisfiable)
+	assert.Error(t, rg.ValidateReader("unknown", rg.Behavior(), rg.Environment()))
+}
+
+func TestAccessSpecIDs(t *testing.T) {
+	rg, ds, tsClose := addReaderHelper(t)
+	defer tsClose()
+
+	zebraCaps := &GetReaderCapabilitiesResponse{}
+	require.NoError(t, json.Unmarshal([]byte(PENZebraCap), zebraCaps))
+	basic, err := NewBasicDevice(zebraCaps)
+	require.NoError(t, err)
+	rg.readers["basic"] = basic
+
+	ids, err := rg.AccessSpecIDs("test")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+
+	b := rg.Behavior()
+	b.MemoryReads = []MemoryRead{{Name: "tid", MemoryBank: MemoryBankTID, WordCount: 2}}
+	require.NoError(t, rg.SetBehavior(ds, b))
+	ids, err = rg.AccessSpecIDs("test")
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1}, ids)
+
+	// Impinj Readers perform optimized reads in their ROSpecs, but other Readers ignore it
+	b.ImpinjOptions = &ImpinjOptions{OptimizedRead: true}
+	require.NoError(t, rg.SetBehavior(ds, b))
+	ids, err = rg.AccessSpecIDs("test")
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+	ids, err = rg.AccessSpecIDs("basic")
+	require.NoError(t, err)
+	assert.Equal(t, []uint32{1}, ids)
+
+	_, err = rg.AccessSpecIDs("unknown")
+	assert.Error(t, err)
+}
+
 func TestStopAll(t *testing.T) {
 	rg, dsClient, tsClose := addReaderHelper(t)
 	defer tsClose()
@@ -5,7 +5,12 @@
 
 package llrp
 
-import "encoding/binary"
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math"
+)
 
 const hexChars = "0123456789abcdef"
 
@@ -28,6 +33,60 @@ func wordsToHex(src []uint16) string {
 	return string(dst)
 }
 
+// HexToWords converts a hex string to an array of 16-bit words.
+//
+// It is the inverse of wordsToHex,
+// so the string must encode a whole number of words.
+func HexToWords(src string) ([]uint16, error) {
+	data, err := hex.DecodeString(src)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data)%2 != 0 {
+		return nil, fmt.Errorf("hex data %q is not a whole number of 16-bit words", src)
+	}
+
+	words := make([]uint16, len(data)/2)
+	for i := range words {
+		words[i] = binary.BigEndian.Uint16(data[2*i:])
+	}
+	return words, nil
+}
+
+// EPCAsHex returns a hex string representation of the tag's EPC,
+// whether the TagReportData reports it as EPC96 or EPCData.
+func (rt *TagReportData) EPCAsHex() string {
+	if len(rt.EPC96.EPC) > 0 {
+		return hex.EncodeToString(rt.EPC96.EPC)
+	}
+	return hex.EncodeToString(rt.EPCData.EPC)
+}
+
+// ExtractPhaseAngle returns the tag's RF phase angle in radians, in [0, 2π),
+// if the report includes Impinj's RFPhaseAngle parameter.
+func (rt *TagReportData) ExtractPhaseAngle() (float64, bool) {
+	for _, c := range rt.Custom {
+		if c.Is(PENImpinj, ImpinjRFPhaseAngle) && len(c.Data) == 2 {
+			// The angle is in 4096ths of a circle.
+			return float64(binary.BigEndian.Uint16(c.Data)%4096) * 2 * math.Pi / 4096, true
+		}
+	}
+	return 0, false
+}
+
+// ExtractDoppler returns the tag's RF Doppler frequency in Hz,
+// if the report includes Impinj's RFDopplerFrequency parameter.
+func (rt *TagReportData) ExtractDoppler() (float64, bool) {
+	for _, c := range rt.Custom {
+		if c.Is(PENImpinj, ImpinjRFDopplerFrequency) && len(c.Data) == 2 {
+			// The frequency is a signed number of sixteenths of a Hz.
+			return float64(int16(binary.BigEndian.Uint16(c.Data))) / 16.0, true // #nosec G115
+		}
+	}
+	return 0, false
+}
+
 // ExtractRSSI returns the RSSI value from TagReportData, if present.
 //
 // If the report includes a Custom Impinj RSSI parameter, it returns that.
@@ -8,6 +8,7 @@ package llrp
 import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"math"
 	"testing"
 )
 
@@ -110,6 +111,29 @@ func TestExtractRSSI(t *testing.T) {
 	}
 }
 
+func TestExtractPhaseAndDoppler(t *testing.T) {
+	rt := TagReportData{}
+	_, ok := rt.ExtractPhaseAngle()
+	assert.False(t, ok)
+	_, ok = rt.ExtractDoppler()
+	assert.False(t, ok)
+
+	rt.Custom = []Custom{
+		{VendorID: uint32(PENImpinj), Subtype: ImpinjRFPhaseAngle, Data: []byte{0x08, 0x00}},
+		{VendorID: uint32(PENImpinj), Subtype: ImpinjRFDopplerFrequency, Data: int16ToBytes(-40)},
+	}
+	phase, ok := rt.ExtractPhaseAngle()
+	assert.True(t, ok)
+	assert.InDelta(t, math.Pi, phase, 1e-9)
+	doppler, ok := rt.ExtractDoppler()
+	assert.True(t, ok)
+	assert.Equal(t, -2.5, doppler)
+
+	rt.Custom[0].Data = []byte{1}
+	_, ok = rt.ExtractPhaseAngle()
+	assert.False(t, ok)
+}
+
 func TestWordsToHex(t *testing.T) {
 	var tests = []struct {
 		name  string
@@ -170,6 +194,16 @@ func TestWordsToHex(t *testing.T) {
 		})
 	}
 
+	// Re-use the input data for testing HexToWords as well
+	for _, test := range tests {
+		test := test
+		t.Run("HexToWords_"+test.name, func(t *testing.T) {
+			words, err := HexToWords(test.want)
+			require.NoError(t, err)
+			assert.Equal(t, test.words, words)
+		})
+	}
+
 	// Re-use the input data for testing ReadDataAsHex as well
 	for _, test := range tests {
 		test := test
@@ -188,6 +222,13 @@ func TestWordsToHex(t *testing.T) {
 	}
 }
 
+func TestHexToWords_invalid(t *testing.T) {
+	for _, data := range []string{"0", "abc", "abcdef", "zzzz"} {
+		_, err := HexToWords(data)
+		assert.Error(t, err, "expected an error for %q", data)
+	}
+}
+
 func TestReadDataAsHex(t *testing.T) {
 	// Note: See also `TestWordsToHex` for more tests of `ReadDataAsHex`
 	tests := []struct {
@@ -40,10 +40,35 @@ type ImpinjParamSubtype = CustomParamSubtype
 const (
 	ImpinjEnablePeakRSSI           = ImpinjParamSubtype(53)
 	ImpinjPeakRSSI                 = ImpinjParamSubtype(57)
+	ImpinjEnableRFPhaseAngle       = ImpinjParamSubtype(52)
+	ImpinjRFPhaseAngle             = ImpinjParamSubtype(56)
+	ImpinjEnableRFDopplerFrequency = ImpinjParamSubtype(67)
+	ImpinjRFDopplerFrequency       = ImpinjParamSubtype(68)
+	ImpinjEnableOptimizedRead      = ImpinjParamSubtype(65)
 	ImpinjTagReportContentSelector = ImpinjParamSubtype(50)
 	ImpinjSearchMode               = ImpinjParamSubtype(23)
+
+	// These configure and report xArray and xSpan gateways' Location and Direction modes.
+	ImpinjPlacementConfiguration = ImpinjParamSubtype(1540)
+	ImpinjLISpec                 = ImpinjParamSubtype(1541)
+	ImpinjLocationConfig         = ImpinjParamSubtype(1542)
+	ImpinjC1G2LocationConfig     = ImpinjParamSubtype(1543)
+	ImpinjLocationReporting      = ImpinjParamSubtype(1544)
+	ImpinjLocationReportData     = ImpinjParamSubtype(1546)
+	ImpinjDISpec                 = ImpinjParamSubtype(1547)
+	ImpinjDirectionSectors       = ImpinjParamSubtype(1548)
+	ImpinjDirectionConfig        = ImpinjParamSubtype(1549)
+	ImpinjC1G2DirectionConfig    = ImpinjParamSubtype(1551)
+	ImpinjExtendedTagInformation = ImpinjParamSubtype(1552)
+	ImpinjDirectionReporting     = ImpinjParamSubtype(1553)
+	ImpinjDirectionReportData    = ImpinjParamSubtype(1554)
 )
 
+// impinjMaxOptimizedReads is the most C1G2Reads we send in EnableOptimizedRead.
+// Impinj Readers accept two, but a TagReportData holds one C1G2ReadOpSpecResult,
+// so the second read's result wouldn't reach us.
+const impinjMaxOptimizedReads = 1
+
 // impinjSearchMode is like a really limited version of standard state-aware filtering
 // with added ambiguity about what C1G2 commands the Reader might send.
 type impinjSearchMode = uint16